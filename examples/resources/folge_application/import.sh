@@ -0,0 +1,6 @@
+# Applications can be imported by their ID
+terraform import folge_application.example 1
+
+# or by their name or identifier
+terraform import folge_application.example name:Checkout
+terraform import folge_application.example uuid:0b6f7d43-0c2e-4c1b-9a4e-3f1f4c2b8a7d
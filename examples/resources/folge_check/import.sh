@@ -0,0 +1,5 @@
+# Checks of any type can be imported by <application_id>/<datasource_id>/<check_id>
+terraform import folge_check.example 1/2/3
+
+# or by their name within the datasource
+terraform import folge_check.example "1/2/label:My check"
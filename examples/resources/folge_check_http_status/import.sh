@@ -0,0 +1,5 @@
+# Checks can be imported by <application_id>/<datasource_id>/<check_id>
+terraform import folge_check_http_status.example 1/2/3
+
+# or by their name within the datasource
+terraform import folge_check_http_status.example "1/2/label:My check"
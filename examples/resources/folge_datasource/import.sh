@@ -0,0 +1,5 @@
+# Datasources can be imported by <application_id>/<datasource_id>
+terraform import folge_datasource.example 1/2
+
+# or by their name within the application
+terraform import folge_datasource.example "1/label:My datasource"
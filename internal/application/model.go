@@ -1,14 +1,19 @@
 package application
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/labd/terraform-provider-folge/internal/folge"
 )
 
 type ApplicationModel struct {
-	ID   types.Int64  `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Identifier    types.String `tfsdk:"identifier"`
+	DataSourceIDs types.List   `tfsdk:"datasource_ids"`
 }
 
 func (m *ApplicationModel) toCreateInput() folge.ApplicationsCreateJSONRequestBody {
@@ -24,5 +29,26 @@ func (m *ApplicationModel) toUpdateInput() folge.ApplicationsUpdateJSONRequestBo
 
 func (m *ApplicationModel) fromRemote(i folge.Application) error {
 	m.ID = types.Int64Value(int64(*i.Id))
+	m.Name = types.StringValue(i.Name)
+
+	m.Identifier = types.StringNull()
+	if i.Identifier != nil {
+		m.Identifier = types.StringValue(i.Identifier.String())
+	}
+	return nil
+}
+
+// setDataSourceIDs sets the IDs of the datasources in the application, which
+// the API only returns from a separate endpoint.
+func (m *ApplicationModel) setDataSourceIDs(datasourceIds []int) error {
+	ids := make([]attr.Value, len(datasourceIds))
+	for n, id := range datasourceIds {
+		ids[n] = types.Int64Value(int64(id))
+	}
+	list, diags := types.ListValue(types.Int64Type, ids)
+	if diags.HasError() {
+		return fmt.Errorf("invalid datasource IDs: %v", diags)
+	}
+	m.DataSourceIDs = list
 	return nil
 }
@@ -9,10 +9,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/policy"
 	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
@@ -20,6 +24,7 @@ import (
 var (
 	_ resource.Resource                = &applicationResource{}
 	_ resource.ResourceWithConfigure   = &applicationResource{}
+	_ resource.ResourceWithModifyPlan  = &applicationResource{}
 	_ resource.ResourceWithImportState = &applicationResource{}
 )
 
@@ -31,6 +36,7 @@ func NewApplicationResource() resource.Resource {
 // applicationResource is the resource implementation.
 type applicationResource struct {
 	client folge.ClientWithResponsesInterface
+	policy *policy.Policy
 }
 
 // Metadata returns the data source type name.
@@ -54,17 +60,56 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Description: "The name.",
 				Required:    true,
 			},
+			"identifier": schema.StringAttribute{
+				Description: "The UUID of the application, which does not change when the application is renamed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"datasource_ids": schema.ListAttribute{
+				Description: "The IDs of the datasources in the application, refreshed on every read.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
 // Configure adds the provider configured client to the data source.
-func (r *applicationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (r *applicationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = utils.GetClient(req.ProviderData)
+	data, diags := utils.GetProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = data.Client
+	r.policy = data.Policy
+}
+
+// ModifyPlan enforces the provider policy.
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ApplicationModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.policy.ValidateLabel(path.Root("name"), plan.Name)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -109,6 +154,15 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// A new application has no datasources yet
+	if err := plan.setDataSourceIDs(nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating application",
+			"Could not create application, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -129,6 +183,11 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 
 	id := int(state.ID.ValueInt64())
 	content, err := r.client.ApplicationsRetrieveWithResponse(ctx, id)
+	if utils.IsNotFound(content, err) {
+		resp.Diagnostics.Append(utils.NotFoundWarning("application", id, ""))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if d := utils.CheckGetError("application", id, content, err); d != nil {
 		resp.Diagnostics.Append(d)
 		return
@@ -145,6 +204,18 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	datasourceIds, err := utils.DataSourceIDs(ctx, r.client, id)
+	if err == nil {
+		err = state.setDataSourceIDs(datasourceIds)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Application",
+			fmt.Sprintf("Could not read the datasources of Application ID %d: %s", id, err.Error()),
+		)
+		return
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -223,7 +294,8 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		)
 		return
 	}
-	if content.StatusCode() != http.StatusNoContent {
+	// A 404 means the application is already gone, which is fine
+	if !utils.IsDeleted(content) {
 		resp.Diagnostics.AddError(
 			"Error deleting application",
 			fmt.Sprintf(
@@ -233,9 +305,22 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	d := utils.ConfirmDeleted(ctx, "application", id, func() (utils.ApiResponse, error) {
+		return r.client.ApplicationsRetrieveWithResponse(ctx, id)
+	})
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
 }
 
 func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// The application is either imported by ID, name:<name> or uuid:<identifier>
+	id, err := utils.ResolveApplicationID(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing application", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
 }
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/policy"
+	"github.com/labd/terraform-provider-folge/internal/testutils"
+)
+
+func TestReadDrift(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &applicationResource{}
+	s := testutils.Configure(t, r, client)
+
+	id := testutils.CreateApplication(t, client, "Checkout")
+	model := ApplicationModel{
+		ID:            types.Int64Value(int64(id)),
+		Name:          types.StringValue("Checkout"),
+		DataSourceIDs: types.ListNull(types.Int64Type),
+	}
+	dsId := testutils.CreateDataSource(t, client, id, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+
+	_, err := client.ApplicationsUpdateWithResponse(ctx, id, folge.ApplicationRequest{Name: "Payments"})
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state ApplicationModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "Payments", state.Name.ValueString())
+	assert.Len(t, state.Identifier.ValueString(), 36)
+	assert.Equal(t, []attr.Value{types.Int64Value(int64(dsId))}, state.DataSourceIDs.Elements())
+
+	// The unchanged configuration shows the drift in the plan
+	assert.Equal(t, []string{"name"}, testutils.ConfigDiff(t, s, resp.State, model))
+}
+
+func TestReadRemoved(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &applicationResource{}
+	s := testutils.Configure(t, r, client)
+
+	id := testutils.CreateApplication(t, client, "Checkout")
+	model := ApplicationModel{
+		ID:            types.Int64Value(int64(id)),
+		Name:          types.StringValue("Checkout"),
+		DataSourceIDs: types.ListNull(types.Int64Type),
+	}
+
+	_, err := client.ApplicationsDestroyWithResponse(ctx, id)
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &applicationResource{}
+	s := testutils.Configure(t, r, client)
+
+	id := testutils.CreateApplication(t, client, "Checkout")
+	otherId := testutils.CreateApplication(t, client, "Search")
+	model := ApplicationModel{
+		ID:            types.Int64Value(int64(id)),
+		Name:          types.StringValue("Checkout"),
+		DataSourceIDs: types.ListNull(types.Int64Type),
+	}
+
+	resp := testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	app, err := client.ApplicationsRetrieveWithResponse(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, app.StatusCode())
+
+	other, err := client.ApplicationsRetrieveWithResponse(ctx, otherId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, other.StatusCode())
+
+	// Deleting an application which is already gone succeeds
+	resp = testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func TestModifyPlanLabelPattern(t *testing.T) {
+	ctx := context.Background()
+	r := &applicationResource{}
+	s := testutils.Configure(t, r, testutils.NewClient(t))
+
+	p, diags := policy.New(&policy.Model{
+		LabelPattern: &policy.LabelPatternModel{
+			Pattern:  types.StringValue("^team-"),
+			Severity: types.StringNull(),
+		},
+	})
+	require.False(t, diags.HasError())
+	r.policy = p
+
+	plan := func(name string) *resource.ModifyPlanResponse {
+		model := ApplicationModel{
+			ID:            types.Int64Unknown(),
+			Name:          types.StringValue(name),
+			Identifier:    types.StringUnknown(),
+			DataSourceIDs: types.ListUnknown(types.Int64Type),
+		}
+		raw := testutils.State(t, s, model).Raw
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: raw}}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: s, Raw: raw},
+			Plan:   tfsdk.Plan{Schema: s, Raw: raw},
+			State:  tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+		}, resp)
+		return resp
+	}
+
+	assert.False(t, plan("team-checkout").Diagnostics.HasError())
+	resp := plan("checkout")
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Policy violation", resp.Diagnostics[0].Summary())
+}
@@ -0,0 +1,237 @@
+package capabilities
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaPath is the location of the OpenAPI document of a Folge server.
+const SchemaPath = "/api/openapi.yaml"
+
+// Capabilities describes the check types, datasource types and fields
+// supported by the Folge server. A nil Capabilities supports everything, which
+// is used when the server does not expose its OpenAPI document.
+type Capabilities struct {
+	Version string
+
+	checkTypes      map[string]bool
+	dataSourceTypes map[string]bool
+	schemas         map[string]schema
+}
+
+type schema struct {
+	properties map[string]bool
+	enum       map[string]bool
+}
+
+type document struct {
+	Info struct {
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties    map[string]any `yaml:"properties"`
+			Enum          []string       `yaml:"enum"`
+			Discriminator *struct {
+				Mapping map[string]string `yaml:"mapping"`
+			} `yaml:"discriminator"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// Detect fetches the OpenAPI document from the server and parses it.
+func Detect(ctx context.Context, client *http.Client, server string, editor func(context.Context, *http.Request) error) (*Capabilities, error) {
+	u, err := url.JoinPath(server, SchemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if editor != nil {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", res.StatusCode, u)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(body)
+}
+
+// Parse reads the capabilities from an OpenAPI document in either YAML or
+// JSON format.
+func Parse(data []byte) (*Capabilities, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	c := &Capabilities{
+		Version: doc.Info.Version,
+		schemas: map[string]schema{},
+	}
+
+	for name, s := range doc.Components.Schemas {
+		item := schema{
+			properties: map[string]bool{},
+			enum:       map[string]bool{},
+		}
+		for key := range s.Properties {
+			item.properties[key] = true
+		}
+		for _, value := range s.Enum {
+			item.enum[value] = true
+		}
+		c.schemas[name] = item
+
+		if s.Discriminator == nil || len(s.Discriminator.Mapping) == 0 {
+			continue
+		}
+		types := map[string]bool{}
+		for value := range s.Discriminator.Mapping {
+			types[value] = true
+		}
+		switch name {
+		case "Check":
+			c.checkTypes = types
+		case "DataSource":
+			c.dataSourceTypes = types
+		}
+	}
+
+	return c, nil
+}
+
+// SupportsCheckType reports whether the server knows the check type, e.g.
+// `http-status`.
+func (c *Capabilities) SupportsCheckType(value string) bool {
+	if c == nil || c.checkTypes == nil {
+		return true
+	}
+	return c.checkTypes[value]
+}
+
+// SupportsDataSourceType reports whether the server knows the datasource
+// type, e.g. `http`.
+func (c *Capabilities) SupportsDataSourceType(value string) bool {
+	if c == nil || c.dataSourceTypes == nil {
+		return true
+	}
+	return c.dataSourceTypes[value]
+}
+
+// SupportsField reports whether the schema with the given name has the field.
+func (c *Capabilities) SupportsField(name string, field string) bool {
+	if c == nil {
+		return true
+	}
+	s, ok := c.schemas[name]
+	if !ok {
+		return true
+	}
+	return s.properties[field]
+}
+
+// SupportsEnumValue reports whether the enum with the given name contains the
+// value.
+func (c *Capabilities) SupportsEnumValue(name string, value string) bool {
+	if c == nil {
+		return true
+	}
+	s, ok := c.schemas[name]
+	if !ok || len(s.enum) == 0 {
+		return true
+	}
+	return s.enum[value]
+}
+
+// RequireCheckType returns an error diagnostic when the check type is not
+// supported.
+func (c *Capabilities) RequireCheckType(value string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !c.SupportsCheckType(value) {
+		diags.AddError(
+			"Unsupported check type",
+			fmt.Sprintf("The check type %q is not supported by this Folge server version%s.", value, c.versionSuffix()),
+		)
+	}
+	return diags
+}
+
+// RequireDataSourceType returns an error diagnostic when the datasource type
+// is not supported.
+func (c *Capabilities) RequireDataSourceType(value string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !c.SupportsDataSourceType(value) {
+		diags.AddError(
+			"Unsupported datasource type",
+			fmt.Sprintf("The datasource type %q is not supported by this Folge server version%s.", value, c.versionSuffix()),
+		)
+	}
+	return diags
+}
+
+// RequireField returns an error diagnostic for the attribute when the field
+// of the schema is not supported.
+func (c *Capabilities) RequireField(attr path.Path, name string, field string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !c.SupportsField(name, field) {
+		diags.AddAttributeError(
+			attr,
+			"Unsupported attribute",
+			fmt.Sprintf("%s is not supported by this Folge server version%s.", attributeName(attr), c.versionSuffix()),
+		)
+	}
+	return diags
+}
+
+// RequireEnumValue returns an error diagnostic for the attribute when the
+// value of the enum is not supported.
+func (c *Capabilities) RequireEnumValue(attr path.Path, name string, value string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !c.SupportsEnumValue(name, value) {
+		diags.AddAttributeError(
+			attr,
+			"Unsupported value",
+			fmt.Sprintf("%s %q is not supported by this Folge server version%s.", attributeName(attr), value, c.versionSuffix()),
+		)
+	}
+	return diags
+}
+
+func (c *Capabilities) versionSuffix() string {
+	if c == nil || c.Version == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", c.Version)
+}
+
+func attributeName(attr path.Path) string {
+	steps := attr.Steps()
+	if len(steps) == 0 {
+		return attr.String()
+	}
+	return steps[len(steps)-1].String()
+}
@@ -0,0 +1,91 @@
+package capabilities
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const openapiDocument = `
+openapi: 3.0.3
+info:
+  title: Folge
+  version: 1.2.0
+components:
+  schemas:
+    Check:
+      oneOf:
+        - $ref: '#/components/schemas/HttpStatusCheckTyped'
+      discriminator:
+        propertyName: type
+        mapping:
+          http-status: '#/components/schemas/HttpStatusCheckTyped'
+    DatatypeEnum:
+      enum:
+        - bool
+        - int
+      type: string
+    HttpStatusCheckTypedRequest:
+      type: object
+      properties:
+        label:
+          type: string
+        status_code:
+          type: integer
+`
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(openapiDocument))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.0", c.Version)
+	assert.True(t, c.SupportsCheckType("http-status"))
+	assert.False(t, c.SupportsCheckType("json-value"))
+
+	// No discriminator for datasources, so everything is allowed
+	assert.True(t, c.SupportsDataSourceType("http"))
+
+	assert.True(t, c.SupportsField("HttpStatusCheckTypedRequest", "label"))
+	assert.False(t, c.SupportsField("HttpStatusCheckTypedRequest", "enabled"))
+	assert.True(t, c.SupportsField("UnknownSchema", "enabled"))
+
+	assert.True(t, c.SupportsEnumValue("DatatypeEnum", "int"))
+	assert.False(t, c.SupportsEnumValue("DatatypeEnum", "datetime"))
+
+	diags := c.RequireField(path.Root("enabled"), "HttpStatusCheckTypedRequest", "enabled")
+	require.True(t, diags.HasError())
+	assert.Equal(t, "enabled is not supported by this Folge server version (1.2.0).", diags[0].Detail())
+}
+
+func TestNilCapabilities(t *testing.T) {
+	var c *Capabilities
+
+	assert.True(t, c.SupportsCheckType("json-value"))
+	assert.True(t, c.SupportsDataSourceType("http"))
+	assert.True(t, c.SupportsField("HttpStatusCheckTypedRequest", "enabled"))
+	assert.True(t, c.SupportsEnumValue("DatatypeEnum", "datetime"))
+	assert.Empty(t, c.RequireCheckType("json-value"))
+}
+
+func TestDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != SchemaPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(openapiDocument))
+	}))
+	defer server.Close()
+
+	c, err := Detect(context.Background(), server.Client(), server.URL, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", c.Version)
+
+	_, err = Detect(context.Background(), server.Client(), server.URL+"/other", nil)
+	assert.Error(t, err)
+}
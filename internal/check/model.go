@@ -0,0 +1,351 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/jsoncheck"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/utils"
+)
+
+const (
+	typeHttpStatus = "http-status"
+	typeJsonData   = "json-value"
+)
+
+// checkBlocks maps each check type to the block holding its settings.
+var checkBlocks = map[string]string{
+	typeHttpStatus: "http_status",
+	typeJsonData:   "json_data",
+}
+
+// checkSchemas maps each check type to the name of its request schema in the
+// OpenAPI document of the server.
+var checkSchemas = map[string]string{
+	typeHttpStatus: "HttpStatusCheckTypedRequest",
+	typeJsonData:   "JsonDataCheckTypedRequest",
+}
+
+// commonFields are the fields shared by checks of all types, which are set
+// with the attributes of the resource instead of the raw JSON.
+var commonFields = []string{"id", "type", "label", "enabled", "crontab"}
+
+type CheckModel struct {
+	ID            types.Int64      `tfsdk:"id"`
+	ApplicationID types.Int64      `tfsdk:"application_id"`
+	DataSourceID  types.Int64      `tfsdk:"datasource_id"`
+	Type          types.String     `tfsdk:"type"`
+	Name          types.String     `tfsdk:"name"`
+	Crontab       crontab.Value    `tfsdk:"crontab"`
+	Timezone      types.String     `tfsdk:"timezone"`
+	Enabled       types.Bool       `tfsdk:"enabled"`
+	HttpStatus    *HttpStatusModel `tfsdk:"http_status"`
+	JsonData      *JsonDataModel   `tfsdk:"json_data"`
+	RawJSON       types.String     `tfsdk:"raw_json"`
+
+	CrontabDescription types.String `tfsdk:"crontab_description"`
+	NextRuns           types.List   `tfsdk:"next_runs"`
+}
+
+// HttpStatusModel holds the settings of http-status checks.
+type HttpStatusModel struct {
+	StatusCode types.Int64 `tfsdk:"status_code"`
+}
+
+// JsonDataModel holds the settings of json-value checks.
+type JsonDataModel struct {
+	Path     jsonpath.Value `tfsdk:"path"`
+	DataType types.String   `tfsdk:"datatype"`
+	Operator types.String   `tfsdk:"operator"`
+	Value    types.Dynamic  `tfsdk:"value"`
+}
+
+// isRaw reports whether the check is of a type without a block, which is
+// configured with raw_json.
+func (m *CheckModel) isRaw() bool {
+	_, ok := checkBlocks[m.Type.ValueString()]
+	return !ok
+}
+
+// blocks returns the check type blocks by name, and whether they are set.
+func (m *CheckModel) blocks() map[string]bool {
+	return map[string]bool{
+		"http_status": m.HttpStatus != nil,
+		"json_data":   m.JsonData != nil,
+	}
+}
+
+func (m *CheckModel) toCreateInput() (folge.ApplicationsDataSourcesChecksCreateJSONRequestBody, error) {
+	req, err := m.createRequest()
+	return folge.ApplicationsDataSourcesChecksCreateJSONRequestBody(req), err
+}
+
+func (m *CheckModel) toUpdateInput() (folge.ApplicationsDataSourcesChecksUpdateJSONRequestBody, error) {
+	req, err := m.createRequest()
+	return folge.ApplicationsDataSourcesChecksUpdateJSONRequestBody(req), err
+}
+
+func (m *CheckModel) createRequest() (folge.Check, error) {
+	req := folge.Check{}
+	switch m.Type.ValueString() {
+	case typeHttpStatus:
+		data := folge.HttpStatusCheckTyped{
+			Crontab:    crontab.ToRemote(m.Crontab),
+			Enabled:    m.Enabled.ValueBoolPointer(),
+			Label:      m.Name.ValueString(),
+			StatusCode: int(m.HttpStatus.StatusCode.ValueInt64()),
+		}
+		if err := req.FromHttpStatusCheckTyped(data); err != nil {
+			return req, err
+		}
+
+	case typeJsonData:
+		data := folge.JsonDataCheckTyped{
+			Crontab:  crontab.ToRemote(m.Crontab),
+			Enabled:  m.Enabled.ValueBoolPointer(),
+			Label:    m.Name.ValueString(),
+			Datatype: folge.DatatypeEnum(m.JsonData.DataType.ValueString()),
+			Operator: folge.OperatorEnum(m.JsonData.Operator.ValueString()),
+			Path:     m.JsonData.Path.ValueString(),
+		}
+		val, err := jsoncheck.ValueToRemote(data.Datatype, m.JsonData.Value)
+		if err != nil {
+			return req, err
+		}
+		data.Value = val
+		if err := req.FromJsonDataCheckTyped(data); err != nil {
+			return req, err
+		}
+
+	default:
+		// Types unknown to this provider version are sent as configured
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(m.RawJSON.ValueString()), &fields); err != nil || fields == nil {
+			return req, fmt.Errorf("raw_json must be a JSON object: %s", m.RawJSON.ValueString())
+		}
+
+		common := map[string]any{"label": m.Name.ValueString()}
+		if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+			common["enabled"] = m.Enabled.ValueBool()
+		}
+		if stored := crontab.ToRemote(m.Crontab); stored != nil {
+			common["crontab"] = *stored
+		}
+		for name, value := range common {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return req, err
+			}
+			fields[name] = data
+		}
+
+		raw := utils.RawObject{Type: m.Type.ValueString(), Fields: fields}
+		data, err := raw.MarshalJSON()
+		if err != nil {
+			return req, err
+		}
+		if err := req.UnmarshalJSON(data); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
+
+func (m *CheckModel) fromRemote(i folge.Check, applicationId int, datasourceId int) error {
+	raw, err := utils.ParseRaw(i)
+	if err != nil {
+		return err
+	}
+
+	m.ApplicationID = types.Int64Value(int64(applicationId))
+	m.DataSourceID = types.Int64Value(int64(datasourceId))
+
+	// Only the block matching the type is set, so a changed type shows up as
+	// a diff on the blocks as well
+	var prior types.Dynamic
+	if m.JsonData != nil {
+		prior = m.JsonData.Value
+	}
+	priorRaw := m.RawJSON
+	m.HttpStatus = nil
+	m.JsonData = nil
+	m.RawJSON = types.StringNull()
+
+	var stored *string
+	switch raw.Type {
+	case typeHttpStatus:
+		d, err := i.AsHttpStatusCheckTyped()
+		if err != nil {
+			return err
+		}
+		m.ID = types.Int64Value(int64(*d.Id))
+		m.Name = types.StringValue(d.Label)
+		m.Enabled = utils.EnabledValue(d.Enabled)
+		stored = d.Crontab
+		m.HttpStatus = &HttpStatusModel{
+			StatusCode: types.Int64Value(int64(d.StatusCode)),
+		}
+
+	case typeJsonData:
+		d, err := i.AsJsonDataCheckTyped()
+		if err != nil {
+			return err
+		}
+		m.ID = types.Int64Value(int64(*d.Id))
+		m.Name = types.StringValue(d.Label)
+		m.Enabled = utils.EnabledValue(d.Enabled)
+		stored = d.Crontab
+		value, err := jsoncheck.ValueFromRemote(d.Datatype, d.Value, prior)
+		if err != nil {
+			return err
+		}
+		m.JsonData = &JsonDataModel{
+			Path:     jsonpath.NewValue(d.Path),
+			DataType: types.StringValue(string(d.Datatype)),
+			Operator: types.StringValue(string(d.Operator)),
+			Value:    value,
+		}
+
+	default:
+		var d struct {
+			Id      int     `json:"id"`
+			Label   string  `json:"label"`
+			Enabled *bool   `json:"enabled"`
+			Crontab *string `json:"crontab"`
+		}
+		data, err := i.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		m.ID = types.Int64Value(int64(d.Id))
+		m.Name = types.StringValue(d.Label)
+		m.Enabled = utils.EnabledValue(d.Enabled)
+		stored = d.Crontab
+		m.RawJSON, err = rawFromRemote(raw, priorRaw)
+		if err != nil {
+			return err
+		}
+	}
+	m.Type = types.StringValue(raw.Type)
+
+	m.Crontab = crontab.FromRemote(stored, m.Crontab)
+	m.CrontabDescription = crontab.DescriptionValue(m.Crontab)
+	if m.NextRuns.IsUnknown() {
+		m.NextRuns = crontab.NextRunsValue(m.Crontab, m.Timezone, time.Now())
+	}
+	return nil
+}
+
+// rawFromRemote returns the fields of a check of an unknown type as JSON,
+// without the common fields. Only the fields in the prior JSON are returned
+// when it is known, as the server may return read-only fields as well. The
+// prior JSON is kept when it only differs in formatting.
+func rawFromRemote(raw utils.RawObject, prior types.String) (types.String, error) {
+	var configured map[string]json.RawMessage
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if err := json.Unmarshal([]byte(prior.ValueString()), &configured); err != nil {
+			return types.StringNull(), fmt.Errorf("invalid raw_json: %w", err)
+		}
+	}
+
+	fields := map[string]json.RawMessage{}
+	for name, value := range raw.Fields {
+		if slices.Contains(commonFields, name) {
+			continue
+		}
+		if _, ok := configured[name]; configured != nil && !ok {
+			continue
+		}
+		fields[name] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	result, err := normalizeJSON(string(data))
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	if configured != nil {
+		if normalized, err := normalizeJSON(prior.ValueString()); err == nil && normalized == result {
+			return prior, nil
+		}
+	}
+	return types.StringValue(result), nil
+}
+
+// mergeRemote adds the fields which are only set on the server to the update
+// request, like those added by a later server version. PUT replaces the whole
+// check, so they would be dropped otherwise. Fields in the prior raw_json are
+// managed by Terraform, so they are removed when they are left out of the new
+// raw_json. The common fields are always set by the resource.
+func mergeRemote(req folge.CheckRequest, current folge.Check, prior types.String) (folge.CheckRequest, error) {
+	sent, err := utils.ParseRaw(req)
+	if err != nil {
+		return req, err
+	}
+	remote, err := utils.ParseRaw(current)
+	if err != nil {
+		return req, err
+	}
+	if remote.Type != sent.Type {
+		return req, nil
+	}
+
+	var managed map[string]json.RawMessage
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if err := json.Unmarshal([]byte(prior.ValueString()), &managed); err != nil {
+			return req, fmt.Errorf("invalid raw_json: %w", err)
+		}
+	}
+
+	for name, value := range remote.Fields {
+		if slices.Contains(commonFields, name) {
+			continue
+		}
+		if _, ok := managed[name]; ok {
+			continue
+		}
+		if _, ok := sent.Fields[name]; !ok {
+			sent.Fields[name] = value
+		}
+	}
+	data, err := sent.MarshalJSON()
+	if err != nil {
+		return req, err
+	}
+	var result folge.CheckRequest
+	if err := result.UnmarshalJSON(data); err != nil {
+		return req, err
+	}
+	return result, nil
+}
+
+// normalizeJSON returns the JSON document without whitespace and with the
+// keys of all objects sorted, like jsonencode does. Numbers are kept as is.
+func normalizeJSON(value string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return "", err
+	}
+	result, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
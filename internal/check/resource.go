@@ -0,0 +1,639 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/jsoncheck"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/policy"
+	"github.com/labd/terraform-provider-folge/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &checkResource{}
+	_ resource.ResourceWithConfigure      = &checkResource{}
+	_ resource.ResourceWithValidateConfig = &checkResource{}
+	_ resource.ResourceWithModifyPlan     = &checkResource{}
+	_ resource.ResourceWithImportState    = &checkResource{}
+)
+
+// NewCheckResource is a helper function to simplify the provider implementation.
+func NewCheckResource() resource.Resource {
+	return &checkResource{}
+}
+
+// checkResource is the resource implementation.
+type checkResource struct {
+	client       folge.ClientWithResponsesInterface
+	capabilities *capabilities.Capabilities
+	policy       *policy.Policy
+	defaults     *utils.CheckDefaults
+	cache        *utils.Cache
+}
+
+// Metadata returns the data source type name.
+func (r *checkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check"
+}
+
+// Schema defines the schema for the data source.
+func (r *checkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A check of any type. The settings of the type are set in the block matching the type.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The ID of the check",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_id": schema.Int64Attribute{
+				Description: "The ID of the applicaton. Changing it replaces the check.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"datasource_id": schema.Int64Attribute{
+				Description: "The ID of the datasource. Changing it replaces the check.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name.",
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the check is enabled. Defaults to the provider defaults, or true.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"crontab": schema.StringAttribute{
+				Description: "The crontab, a 5-field expression or a macro like `@hourly`. Defaults to the crontab in the provider defaults.",
+				Optional:    true,
+				Computed:    true,
+				CustomType:  crontab.Type{},
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The timezone of the times in `next_runs`, like `Europe/Amsterdam`. Defaults to UTC. The Folge API has no timezone setting, so the server runs the crontab in UTC regardless.",
+				Optional:    true,
+				Validators: []validator.String{
+					crontab.TimezoneValidator(),
+				},
+			},
+			"crontab_description": schema.StringAttribute{
+				Description: "A human-readable description of the crontab, in UTC like the server runs it.",
+				Computed:    true,
+			},
+			"next_runs": schema.ListAttribute{
+				Description: "The next runs of the check, refreshed on every read.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the check, like `http-status` or `json-value`. Types without a block, which are not supported by this provider version yet, are configured with `raw_json`. Changing it replaces the check.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"raw_json": schema.StringAttribute{
+				Description: "The settings of a check of a type without a block, as a JSON object like `jsonencode({ host = \"example.com\" })`. The name, enabled and crontab are set with their attributes instead. Fields which were never in `raw_json` keep their value on the server, fields removed from it are removed from the check.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"http_status": schema.SingleNestedBlock{
+				Description: "The settings of `http-status` checks.",
+				Attributes: map[string]schema.Attribute{
+					"status_code": schema.Int64Attribute{
+						Description: "The expected HTTP status code.",
+						Optional:    true,
+					},
+				},
+			},
+			"json_data": schema.SingleNestedBlock{
+				Description: "The settings of `json-value` checks.",
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						Description: "The json path to check, like `$.status`, `items[0].name` or `$['key.with.dots']`. The leading `$.` is optional.",
+						Optional:    true,
+						CustomType:  jsonpath.Type{},
+					},
+					"datatype": schema.StringAttribute{
+						Description: "The data type of the property. The `null` datatype takes no value, and checks whether the property is null (`eq`), not null (`neq`), present (`exists`) or absent (`not_exists`). `float` and `null` require a Folge server supporting them.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(jsoncheck.Datatypes()...),
+						},
+					},
+					"operator": schema.StringAttribute{
+						Description: "The operator to use for the check. `gt` and `lt` can only be used with the `int`, `float` and `datetime` datatypes, `exists` and `not_exists` only with the `null` datatype.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(jsoncheck.Operators()...),
+						},
+					},
+					"value": schema.DynamicAttribute{
+						Description: "The value to compare against. Its type must match the datatype: a bool for `bool`, a whole number for `int`, a number for `float`, a string for `str` and an RFC 3339 timestamp string like `2024-01-01T12:00:00Z` for `datetime`. Must not be set for `null`.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *checkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diags := utils.GetProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = data.Client
+	r.capabilities = data.Capabilities
+	r.policy = data.Policy
+	r.defaults = data.Defaults
+	r.cache = data.Cache
+}
+
+// ValidateConfig checks that exactly the block matching the type is set, with
+// all of its required attributes. Types without a block require raw_json.
+func (r *checkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CheckModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The matching block is only known once the type is known
+	if config.Type.IsNull() || config.Type.IsUnknown() {
+		return
+	}
+	expected, ok := checkBlocks[config.Type.ValueString()]
+	if !ok {
+		resp.Diagnostics.Append(validateRawJSON(config)...)
+		return
+	}
+	if !config.RawJSON.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("raw_json"),
+			"Unexpected value",
+			fmt.Sprintf("raw_json can't be set when the type is %q, set the %s block instead.", config.Type.ValueString(), expected),
+		)
+	}
+
+	for name, set := range config.blocks() {
+		switch {
+		case name == expected && !set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing block",
+				fmt.Sprintf("The %s block must be set when the type is %q.", name, config.Type.ValueString()),
+			)
+		case name != expected && set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unexpected block",
+				fmt.Sprintf("The %s block can't be set when the type is %q, set %s instead.", name, config.Type.ValueString(), expected),
+			)
+		}
+	}
+
+	if config.HttpStatus != nil && expected == "http_status" {
+		resp.Diagnostics.Append(requireAttribute(path.Root("http_status").AtName("status_code"), config.HttpStatus.StatusCode)...)
+	}
+	if config.JsonData != nil && expected == "json_data" {
+		data := config.JsonData
+		block := path.Root("json_data")
+		resp.Diagnostics.Append(requireAttribute(block.AtName("path"), data.Path)...)
+		resp.Diagnostics.Append(requireAttribute(block.AtName("datatype"), data.DataType)...)
+		resp.Diagnostics.Append(requireAttribute(block.AtName("operator"), data.Operator)...)
+		if data.DataType.IsNull() || data.DataType.IsUnknown() {
+			return
+		}
+
+		datatype := folge.DatatypeEnum(data.DataType.ValueString())
+		if !data.Operator.IsNull() && !data.Operator.IsUnknown() {
+			operator := folge.OperatorEnum(data.Operator.ValueString())
+			resp.Diagnostics.Append(jsoncheck.ValidateOperator(block.AtName("operator"), operator, datatype)...)
+		}
+		if data.Value.IsNull() && jsoncheck.KnownDatatype(datatype) && jsoncheck.NeedsValue(datatype) {
+			resp.Diagnostics.AddAttributeError(
+				block.AtName("value"),
+				"Missing value",
+				fmt.Sprintf("value must be set when the datatype is %q.", datatype),
+			)
+		}
+		resp.Diagnostics.Append(jsoncheck.ValidateValue(block.AtName("value"), datatype, data.Value)...)
+	}
+}
+
+// validateRawJSON checks that the settings of a check of a type without a
+// block are set as a JSON object, and that no block is set.
+func validateRawJSON(config CheckModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for name, set := range config.blocks() {
+		if set {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Unexpected block",
+				fmt.Sprintf("The %s block can't be set when the type is %q, set raw_json instead.", name, config.Type.ValueString()),
+			)
+		}
+	}
+
+	if config.RawJSON.IsNull() {
+		diags.AddAttributeError(
+			path.Root("raw_json"),
+			"Missing value",
+			fmt.Sprintf("raw_json must be set when the type is %q, which has no block in this provider version.", config.Type.ValueString()),
+		)
+		return diags
+	}
+	if config.RawJSON.IsUnknown() {
+		return diags
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(config.RawJSON.ValueString()), &fields); err != nil || fields == nil {
+		diags.AddAttributeError(path.Root("raw_json"), "Invalid JSON",
+			"raw_json must be a JSON object, like jsonencode({ host = \"example.com\" }).")
+		return diags
+	}
+	for _, name := range commonFields {
+		if _, ok := fields[name]; ok {
+			diags.AddAttributeError(path.Root("raw_json"), "Invalid JSON",
+				fmt.Sprintf("raw_json can't contain the field %q, which is set with the attributes of the resource.", name))
+		}
+	}
+	return diags
+}
+
+// requireAttribute reports an error when the attribute of a block is not set.
+// The attributes are optional in the schema, as blocks of other check types
+// are left out.
+func requireAttribute(attr path.Path, value attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if value.IsNull() {
+		diags.AddAttributeError(attr, "Missing required argument",
+			fmt.Sprintf("The argument %q is required in this block.", attr.Steps()[len(attr.Steps())-1]))
+	}
+	return diags
+}
+
+// ModifyPlan applies the provider defaults, verifies the planned check is
+// supported by the server and enforces the provider policy.
+func (r *checkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.defaults.Apply(ctx, req.Config, &resp.Plan)...)
+	resp.Diagnostics.Append(crontab.PlanSchedule(ctx, req.State, &resp.Plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan CheckModel
+	diags := resp.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.requireSupported(plan)...)
+
+	resp.Diagnostics.Append(r.policy.ValidateLabel(path.Root("name"), plan.Name)...)
+	resp.Diagnostics.Append(r.policy.ValidateCrontab(path.Root("crontab"), plan.Crontab.StringValue)...)
+	resp.Diagnostics.Append(r.policy.ValidateEnabled(path.Root("enabled"), plan.Enabled)...)
+
+	// The number of checks only changes when a check is added to a datasource
+	if !r.policy.HasCheckCount() || r.client == nil {
+		return
+	}
+	if plan.ApplicationID.IsUnknown() || plan.DataSourceID.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.Int64
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("datasource_id"), &prior)...)
+		if resp.Diagnostics.HasError() || prior.Equal(plan.DataSourceID) {
+			return
+		}
+	}
+
+	dsId := utils.AsInt(plan.DataSourceID)
+	count, err := r.cache.ReserveCheck(ctx, r.client, utils.AsInt(plan.ApplicationID), dsId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list checks of datasource %d: %s", dsId, err.Error()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.policy.ValidateCheckCount(path.Root("datasource_id"), dsId, count)...)
+}
+
+// requireSupported verifies the server supports the planned check type and
+// the settings used.
+func (r *checkResource) requireSupported(plan CheckModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Type.IsUnknown() {
+		return diags
+	}
+	schemaName := checkSchemas[plan.Type.ValueString()]
+	diags.Append(r.capabilities.RequireCheckType(plan.Type.ValueString())...)
+	diags.Append(r.capabilities.RequireField(
+		path.Root("crontab"), schemaName, "crontab")...)
+	// Checks are enabled by default, so only disabling one requires support
+	if !plan.Enabled.IsUnknown() && !plan.Enabled.ValueBool() {
+		diags.Append(r.capabilities.RequireField(
+			path.Root("enabled"), schemaName, "enabled")...)
+	}
+	if data := plan.JsonData; data != nil && plan.Type.ValueString() == typeJsonData {
+		block := path.Root("json_data")
+		if !data.DataType.IsUnknown() {
+			diags.Append(r.capabilities.RequireEnumValue(
+				block.AtName("datatype"), "DatatypeEnum", data.DataType.ValueString())...)
+			// Values unknown during validation are checked once they are known
+			diags.Append(jsoncheck.ValidateValue(
+				block.AtName("value"), folge.DatatypeEnum(data.DataType.ValueString()), data.Value)...)
+		}
+		if !data.Operator.IsUnknown() {
+			diags.Append(r.capabilities.RequireEnumValue(
+				block.AtName("operator"), "OperatorEnum", data.Operator.ValueString())...)
+		}
+	}
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *checkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan CheckModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	input, err := plan.toCreateInput()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating check",
+			"Could not create check, invalid settings: "+err.Error(),
+		)
+		return
+	}
+	appId := utils.AsInt(plan.ApplicationID)
+	dsId := utils.AsInt(plan.DataSourceID)
+
+	content, err := r.client.ApplicationsDataSourcesChecksCreateWithResponse(ctx, appId, dsId, input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating check",
+			"Could not create check, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if content.StatusCode() != http.StatusCreated {
+		resp.Diagnostics.AddError(
+			"Error creating check",
+			fmt.Sprintf(
+				"Could not create check, status code %d error: %s",
+				content.StatusCode(), string(content.Body)),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	sent := plan.Crontab
+	if err := plan.fromRemote(*content.JSON201, appId, dsId); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating check",
+			"Could not create check, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(utils.SetWritten(ctx, &resp.State, plan, sent.StringValue, plan.Crontab.StringValue)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *checkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state CheckModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := int(state.ID.ValueInt64())
+	appId := utils.AsInt(state.ApplicationID)
+	dsId := utils.AsInt(state.DataSourceID)
+	content, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	if utils.IsNotFound(content, err) {
+		reason := utils.MissingParentReason(ctx, r.client, appId, dsId)
+		resp.Diagnostics.Append(utils.NotFoundWarning("check", id, reason))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if d := utils.CheckGetError("check", id, content, err); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	check := *content.JSON200
+
+	// Overwrite items with refreshed state, including the upcoming runs
+	state.NextRuns = types.ListUnknown(types.StringType)
+	if err := state.fromRemote(check, appId, dsId); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Check",
+			fmt.Sprintf("Could not read Check ID %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *checkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan CheckModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	input, err := plan.toUpdateInput()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating check",
+			"Could not update check, invalid settings: "+err.Error(),
+		)
+		return
+	}
+	planId := utils.AsInt(plan.ID)
+	appId := utils.AsInt(plan.ApplicationID)
+	dsId := utils.AsInt(plan.DataSourceID)
+
+	// Keep the fields of the current check which are only set on the server
+	if plan.isRaw() {
+		var prior types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("raw_json"), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, planId)
+		if d := utils.CheckGetError("check", planId, current, err); d != nil {
+			resp.Diagnostics.Append(d)
+			return
+		}
+		if input, err = mergeRemote(input, *current.JSON200, prior); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating check",
+				"Could not update check, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	content, err := r.client.ApplicationsDataSourcesChecksUpdateWithResponse(ctx, appId, dsId, planId, input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating check",
+			"Could not update check, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if content.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Error updating check",
+			fmt.Sprintf(
+				"Could not update check, status code %d error: %s",
+				content.StatusCode(), string(content.Body)),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	sent := plan.Crontab
+	if err := plan.fromRemote(*content.JSON200, appId, dsId); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating check",
+			"Could not update check, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(utils.SetWritten(ctx, &resp.State, plan, sent.StringValue, plan.Crontab.StringValue)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *checkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state CheckModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := utils.AsInt(state.ID)
+	appId := utils.AsInt(state.ApplicationID)
+	dsId := utils.AsInt(state.DataSourceID)
+	content, err := r.client.ApplicationsDataSourcesChecksDestroyWithResponse(ctx, appId, dsId, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting check",
+			"Could not delete check, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	// A 404 means the check is already gone, which is fine
+	if !utils.IsDeleted(content) {
+		resp.Diagnostics.AddError(
+			"Error deleting check",
+			fmt.Sprintf(
+				"Could not delete check, status code %d error: %s",
+				content.StatusCode(), string(content.Body)),
+		)
+		return
+	}
+
+	d := utils.ConfirmDeleted(ctx, "check", id, func() (utils.ApiResponse, error) {
+		return r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	})
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+}
+
+func (r *checkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parents, selector, err := utils.SplitImportID(req.ID, "application_id", "datasource_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	appId, dsId := parents[0], parents[1]
+
+	// The check is either imported by ID or label:<label>
+	id, err := utils.ResolveCheckID(ctx, r.client, appId, dsId, selector)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing check", err.Error())
+		return
+	}
+
+	// Verify the check exists, checks of all types are managed by this resource
+	content, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	if d := utils.CheckGetError("check", id, content, err); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), int64(appId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("datasource_id"), int64(dsId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
+}
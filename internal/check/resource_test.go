@@ -0,0 +1,399 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/mock"
+	"github.com/labd/terraform-provider-folge/internal/testutils"
+)
+
+// setup returns a configured resource and the planned model of a check in a
+// new datasource on the mock backend.
+func setup(t *testing.T, opts ...mock.Option) (folge.ClientWithResponsesInterface, *checkResource, schema.Schema, CheckModel) {
+	client := testutils.NewClient(t, opts...)
+	r := &checkResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+
+	model := CheckModel{
+		ID:                 types.Int64Unknown(),
+		ApplicationID:      types.Int64Value(int64(appId)),
+		DataSourceID:       types.Int64Value(int64(dsId)),
+		Name:               types.StringValue("Status"),
+		Crontab:            crontab.NewValue("*/5 * * * *"),
+		Enabled:            types.BoolValue(true),
+		CrontabDescription: types.StringUnknown(),
+		NextRuns:           types.ListUnknown(types.StringType),
+	}
+	return client, r, s, model
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	status := model
+	status.Type = types.StringValue("http-status")
+	status.HttpStatus = &HttpStatusModel{StatusCode: types.Int64Value(200)}
+	resp := testutils.Create(t, r, s, status)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.NotNil(t, state.HttpStatus)
+	assert.Equal(t, int64(200), state.HttpStatus.StatusCode.ValueInt64())
+	assert.Nil(t, state.JsonData)
+
+	content, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(state.ID.ValueInt64()))
+	require.NoError(t, err)
+	statusCheck, err := content.JSON200.AsHttpStatusCheckTyped()
+	require.NoError(t, err)
+	assert.Equal(t, 200, statusCheck.StatusCode)
+
+	data := model
+	data.Name = types.StringValue("Instances")
+	data.Type = types.StringValue("json-value")
+	data.JsonData = &JsonDataModel{
+		Path:     jsonpath.NewValue("$.instances"),
+		DataType: types.StringValue("int"),
+		Operator: types.StringValue("gt"),
+		Value:    types.DynamicValue(types.NumberValue(big.NewFloat(2))),
+	}
+	resp = testutils.Create(t, r, s, data)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.NotNil(t, state.JsonData)
+	assert.True(t, data.JsonData.Value.Equal(state.JsonData.Value))
+	assert.Nil(t, state.HttpStatus)
+
+	content, err = client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(state.ID.ValueInt64()))
+	require.NoError(t, err)
+	dataCheck, err := content.JSON200.AsJsonDataCheckTyped()
+	require.NoError(t, err)
+	assert.Equal(t, "2", dataCheck.Value)
+	assert.Equal(t, "$.instances", dataCheck.Path)
+}
+
+func TestCreateCrontabDropped(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClientWithoutCrontab(t)
+	r := &checkResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+	model := CheckModel{
+		ID:                 types.Int64Unknown(),
+		ApplicationID:      types.Int64Value(int64(appId)),
+		DataSourceID:       types.Int64Value(int64(dsId)),
+		Name:               types.StringValue("Status"),
+		Type:               types.StringValue("http-status"),
+		HttpStatus:         &HttpStatusModel{StatusCode: types.Int64Value(200)},
+		Crontab:            crontab.NewValue("*/5 * * * *"),
+		Enabled:            types.BoolValue(true),
+		CrontabDescription: types.StringUnknown(),
+		NextRuns:           types.ListUnknown(types.StringType),
+	}
+
+	// The check is kept in the state, so it isn't created twice
+	resp := testutils.Create(t, r, s, model)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Crontab not supported", resp.Diagnostics[0].Summary())
+
+	var state CheckModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.False(t, state.ID.IsNull())
+	assert.True(t, state.Crontab.IsNull())
+}
+
+func TestReadImported(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	check := folge.Check{}
+	require.NoError(t, check.FromJsonDataCheckTyped(folge.JsonDataCheckTyped{
+		Label:    "Healthy",
+		Path:     "$.healthy",
+		Datatype: folge.Bool,
+		Operator: folge.Eq,
+		Value:    "true",
+	}))
+	id := testutils.CreateCheck(t, client, appId, dsId, check)
+
+	// Only the IDs are known after an import
+	imported := CheckModel{
+		ID:            types.Int64Value(int64(id)),
+		ApplicationID: model.ApplicationID,
+		DataSourceID:  model.DataSourceID,
+		NextRuns:      types.ListNull(types.StringType),
+	}
+	resp := testutils.Read(t, r, testutils.State(t, s, imported))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "json-value", state.Type.ValueString())
+	assert.Equal(t, "Healthy", state.Name.ValueString())
+	assert.Nil(t, state.HttpStatus)
+	require.NotNil(t, state.JsonData)
+	assert.Equal(t, "$.healthy", state.JsonData.Path.ValueString())
+	assert.Equal(t, types.DynamicValue(types.BoolValue(true)), state.JsonData.Value)
+}
+
+func TestValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	_, r, s, model := setup(t)
+
+	validate := func(checkType string, modify func(m *CheckModel)) *resource.ValidateConfigResponse {
+		config := model
+		config.Type = types.StringValue(checkType)
+		modify(&config)
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: testutils.State(t, s, config).Raw},
+		}, resp)
+		return resp
+	}
+
+	resp := validate("http-status", func(m *CheckModel) {
+		m.HttpStatus = &HttpStatusModel{StatusCode: types.Int64Value(200)}
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate("http-status", func(m *CheckModel) {})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, `The http_status block must be set when the type is "http-status".`, resp.Diagnostics[0].Detail())
+
+	resp = validate("http-status", func(m *CheckModel) {
+		m.HttpStatus = &HttpStatusModel{StatusCode: types.Int64Value(200)}
+		m.JsonData = &JsonDataModel{DataType: types.StringValue("bool")}
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "The json_data block can't be set")
+
+	resp = validate("http-status", func(m *CheckModel) {
+		m.HttpStatus = &HttpStatusModel{StatusCode: types.Int64Null()}
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `"status_code" is required`)
+
+	resp = validate("json-value", func(m *CheckModel) {
+		m.JsonData = &JsonDataModel{
+			Path:     jsonpath.NewValue("$.healthy"),
+			DataType: types.StringValue("bool"),
+			Operator: types.StringValue("gt"),
+			Value:    types.DynamicValue(types.StringValue("true")),
+		}
+	})
+	require.Len(t, resp.Diagnostics.Errors(), 2)
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `The operator "gt" can't be used with the datatype "bool"`)
+	assert.Contains(t, resp.Diagnostics[1].Detail(), `The value must be a bool`)
+
+	resp = validate("json-value", func(m *CheckModel) {
+		m.JsonData = &JsonDataModel{
+			Path:     jsonpath.NewValue("$.healthy"),
+			DataType: types.StringValue("bool"),
+			Operator: types.StringValue("eq"),
+		}
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "value must be set")
+}
+
+func TestRawJSON(t *testing.T) {
+	// A check of a type added to the server after this provider version,
+	// with a read-only field the configuration doesn't contain
+	check := folge.Check{}
+	require.NoError(t, check.UnmarshalJSON([]byte(
+		`{"type":"ping","id":3,"label":"Ping","enabled":false,"crontab":"@hourly","host":"example.com","timeout":1.50,"last_result":"ok"}`)))
+
+	// Imported checks get all fields
+	var model CheckModel
+	model.NextRuns = types.ListNull(types.StringType)
+	require.NoError(t, model.fromRemote(check, 1, 2))
+	assert.Equal(t, "ping", model.Type.ValueString())
+	assert.Equal(t, int64(3), model.ID.ValueInt64())
+	assert.Equal(t, "Ping", model.Name.ValueString())
+	assert.False(t, model.Enabled.ValueBool())
+	assert.Equal(t, "@hourly", model.Crontab.ValueString())
+	assert.Nil(t, model.HttpStatus)
+	assert.Nil(t, model.JsonData)
+	assert.Equal(t, `{"host":"example.com","last_result":"ok","timeout":1.50}`, model.RawJSON.ValueString())
+
+	// Configured checks only get the configured fields, in their formatting
+	configured := `{ "timeout": 1.50, "host": "example.com" }`
+	model.RawJSON = types.StringValue(configured)
+	require.NoError(t, model.fromRemote(check, 1, 2))
+	assert.Equal(t, configured, model.RawJSON.ValueString())
+
+	// Changes outside of Terraform are detected
+	model.RawJSON = types.StringValue(`{"host":"example.org","timeout":1.50}`)
+	require.NoError(t, model.fromRemote(check, 1, 2))
+	assert.Equal(t, `{"host":"example.com","timeout":1.50}`, model.RawJSON.ValueString())
+
+	// The check is sent back without losing any configured field
+	model.Name = types.StringValue("Ping example")
+	req, err := model.createRequest()
+	require.NoError(t, err)
+	data, err := req.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"ping","label":"Ping example","enabled":false,"crontab":"@hourly","host":"example.com","timeout":1.50}`, string(data))
+	assert.Contains(t, string(data), `"timeout":1.50`)
+}
+
+func TestRawJSONApply(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t, testutils.WithResponseTimeCheck())
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	retrieve := func(id int) map[string]any {
+		content, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+		require.NoError(t, err)
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(content.Body, &fields))
+		return fields
+	}
+
+	// A type added to the mock, which has no block in the provider
+	model.Type = types.StringValue("response-time")
+	model.RawJSON = types.StringValue(`{"max_milliseconds": 500}`)
+	resp := testutils.Create(t, r, s, model)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	id := int(state.ID.ValueInt64())
+	assert.Equal(t, `{"max_milliseconds": 500}`, state.RawJSON.ValueString())
+	remote := retrieve(id)
+	assert.Equal(t, "response-time", remote["type"])
+	assert.Equal(t, "Status", remote["label"])
+	assert.Equal(t, 500.0, remote["max_milliseconds"])
+
+	// A field set outside of Terraform, which isn't in raw_json
+	check := folge.CheckRequest{}
+	require.NoError(t, check.UnmarshalJSON([]byte(
+		`{"type":"response-time","label":"Status","max_milliseconds":500,"percentile":95}`)))
+	content, err := client.ApplicationsDataSourcesChecksUpdateWithResponse(ctx, appId, dsId, id, check)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, content.StatusCode(), string(content.Body))
+
+	read := testutils.Read(t, r, resp.State)
+	require.False(t, read.Diagnostics.HasError(), read.Diagnostics)
+	require.False(t, read.State.Get(ctx, &state).HasError())
+	assert.Equal(t, `{"max_milliseconds": 500}`, state.RawJSON.ValueString())
+
+	// The field is kept when the check is updated
+	state.RawJSON = types.StringValue(`{"max_milliseconds": 800}`)
+	state.NextRuns = types.ListUnknown(types.StringType)
+	update := testutils.Update(t, r, s, read.State, state)
+	require.False(t, update.Diagnostics.HasError(), update.Diagnostics)
+	remote = retrieve(id)
+	assert.Equal(t, 800.0, remote["max_milliseconds"])
+	assert.Equal(t, 95.0, remote["percentile"])
+
+	// Fields managed with raw_json are removed when they are left out
+	require.False(t, update.State.Get(ctx, &state).HasError())
+	state.RawJSON = types.StringValue(`{"max_milliseconds": 800, "percentile": 99}`)
+	update = testutils.Update(t, r, s, update.State, state)
+	require.False(t, update.Diagnostics.HasError(), update.Diagnostics)
+	assert.Equal(t, 99.0, retrieve(id)["percentile"])
+
+	require.False(t, update.State.Get(ctx, &state).HasError())
+	state.RawJSON = types.StringValue(`{"max_milliseconds": 800}`)
+	update = testutils.Update(t, r, s, update.State, state)
+	require.False(t, update.Diagnostics.HasError(), update.Diagnostics)
+	remote = retrieve(id)
+	assert.Equal(t, 800.0, remote["max_milliseconds"])
+	assert.NotContains(t, remote, "percentile")
+}
+
+func TestCreateInvalidRawJSON(t *testing.T) {
+	_, r, s, model := setup(t, testutils.WithResponseTimeCheck())
+
+	// Reported instead of crashing the provider, when validation was skipped
+	// because raw_json was unknown
+	model.Type = types.StringValue("response-time")
+	model.RawJSON = types.StringValue(`[500]`)
+	resp := testutils.Create(t, r, s, model)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Could not create check, invalid settings: raw_json must be a JSON object: [500]", resp.Diagnostics[0].Detail())
+}
+
+func TestValidateConfigRawJSON(t *testing.T) {
+	ctx := context.Background()
+	_, r, s, model := setup(t)
+
+	validate := func(checkType string, modify func(m *CheckModel)) *resource.ValidateConfigResponse {
+		config := model
+		config.Type = types.StringValue(checkType)
+		modify(&config)
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: testutils.State(t, s, config).Raw},
+		}, resp)
+		return resp
+	}
+
+	resp := validate("ping", func(m *CheckModel) {
+		m.RawJSON = types.StringValue(`{"host":"example.com"}`)
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate("ping", func(m *CheckModel) {})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `raw_json must be set when the type is "ping"`)
+
+	resp = validate("ping", func(m *CheckModel) {
+		m.RawJSON = types.StringValue(`["example.com"]`)
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "raw_json must be a JSON object")
+
+	resp = validate("ping", func(m *CheckModel) {
+		m.RawJSON = types.StringValue(`{"label":"Ping"}`)
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `raw_json can't contain the field "label"`)
+
+	resp = validate("ping", func(m *CheckModel) {
+		m.RawJSON = types.StringValue(`{"host":"example.com"}`)
+		m.HttpStatus = &HttpStatusModel{StatusCode: types.Int64Value(200)}
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "The http_status block can't be set")
+
+	resp = validate("http-status", func(m *CheckModel) {
+		m.RawJSON = types.StringValue(`{"host":"example.com"}`)
+		m.HttpStatus = &HttpStatusModel{StatusCode: types.Int64Value(200)}
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `raw_json can't be set when the type is "http-status"`)
+}
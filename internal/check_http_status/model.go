@@ -1,21 +1,27 @@
 package checkhttpstatus
 
 import (
-	"errors"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/labd/terraform-provider-folge/internal/crontab"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
 type CheckHttpStatusModel struct {
-	ID            types.Int64  `tfsdk:"id"`
-	ApplicationID types.Int64  `tfsdk:"application_id"`
-	DataSourceID  types.Int64  `tfsdk:"datasource_id"`
-	Name          types.String `tfsdk:"name"`
-	StatusCode    types.Int64  `tfsdk:"status_code"`
-	Crontab       types.String `tfsdk:"crontab"`
-	Enabled       types.Bool   `tfsdk:"enabled"`
+	ID            types.Int64   `tfsdk:"id"`
+	ApplicationID types.Int64   `tfsdk:"application_id"`
+	DataSourceID  types.Int64   `tfsdk:"datasource_id"`
+	Name          types.String  `tfsdk:"name"`
+	StatusCode    types.Int64   `tfsdk:"status_code"`
+	Crontab       crontab.Value `tfsdk:"crontab"`
+	Timezone      types.String  `tfsdk:"timezone"`
+	Enabled       types.Bool    `tfsdk:"enabled"`
+
+	CrontabDescription types.String `tfsdk:"crontab_description"`
+	NextRuns           types.List   `tfsdk:"next_runs"`
 }
 
 func (m *CheckHttpStatusModel) toCreateInput() folge.ApplicationsDataSourcesChecksCreateJSONRequestBody {
@@ -30,6 +36,7 @@ func (m *CheckHttpStatusModel) toUpdateInput() folge.ApplicationsDataSourcesChec
 
 func (m *CheckHttpStatusModel) createRequest() folge.Check {
 	data := folge.HttpStatusCheckTyped{
+		Crontab:    crontab.ToRemote(m.Crontab),
 		Enabled:    m.Enabled.ValueBoolPointer(),
 		Label:      m.Name.ValueString(),
 		StatusCode: int(m.StatusCode.ValueInt64()),
@@ -43,22 +50,24 @@ func (m *CheckHttpStatusModel) createRequest() folge.Check {
 }
 
 func (m *CheckHttpStatusModel) fromRemote(i folge.Check, applicationId int, datasourceId int) error {
-	t, err := i.ValueByDiscriminator()
+	if err := utils.CheckType(i, "http-status"); err != nil {
+		return err
+	}
+	d, err := i.AsHttpStatusCheckTyped()
 	if err != nil {
 		return err
 	}
 
-	switch d := t.(type) {
-	case folge.HttpStatusCheckTyped:
-		m.ID = types.Int64Value(int64(*d.Id))
-		m.Name = types.StringValue(d.Label)
-		m.ApplicationID = types.Int64Value(int64(applicationId))
-		m.DataSourceID = types.Int64Value(int64(datasourceId))
-		m.Enabled = types.BoolPointerValue(d.Enabled)
-		m.StatusCode = types.Int64Value(int64(d.StatusCode))
-
-	default:
-		return errors.New("unknown data source type")
+	m.ID = types.Int64Value(int64(*d.Id))
+	m.Name = types.StringValue(d.Label)
+	m.ApplicationID = types.Int64Value(int64(applicationId))
+	m.DataSourceID = types.Int64Value(int64(datasourceId))
+	m.Crontab = crontab.FromRemote(d.Crontab, m.Crontab)
+	m.CrontabDescription = crontab.DescriptionValue(m.Crontab)
+	if m.NextRuns.IsUnknown() {
+		m.NextRuns = crontab.NextRunsValue(m.Crontab, m.Timezone, time.Now())
 	}
+	m.Enabled = utils.EnabledValue(d.Enabled)
+	m.StatusCode = types.Int64Value(int64(d.StatusCode))
 	return nil
 }
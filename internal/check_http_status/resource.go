@@ -8,11 +8,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
+	"github.com/labd/terraform-provider-folge/internal/crontab"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/policy"
 	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
@@ -20,6 +24,7 @@ import (
 var (
 	_ resource.Resource                = &checkHttpStatusResource{}
 	_ resource.ResourceWithConfigure   = &checkHttpStatusResource{}
+	_ resource.ResourceWithModifyPlan  = &checkHttpStatusResource{}
 	_ resource.ResourceWithImportState = &checkHttpStatusResource{}
 )
 
@@ -30,7 +35,11 @@ func NewCheckHttpStatusResource() resource.Resource {
 
 // checkHttpStatusResource is the resource implementation.
 type checkHttpStatusResource struct {
-	client folge.ClientWithResponsesInterface
+	client       folge.ClientWithResponsesInterface
+	capabilities *capabilities.Capabilities
+	policy       *policy.Policy
+	defaults     *utils.CheckDefaults
+	cache        *utils.Cache
 }
 
 // Metadata returns the data source type name.
@@ -51,26 +60,49 @@ func (r *checkHttpStatusResource) Schema(_ context.Context, _ resource.SchemaReq
 				},
 			},
 			"application_id": schema.Int64Attribute{
-				Description: "The ID of the applicaton",
+				Description: "The ID of the applicaton. Changing it replaces the check.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"datasource_id": schema.Int64Attribute{
-				Description: "The ID of the datasource",
+				Description: "The ID of the datasource. Changing it replaces the check.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name.",
 				Required:    true,
 			},
 			"enabled": schema.BoolAttribute{
-				Description: "Whether the check is enabled.",
+				Description: "Whether the check is enabled. Defaults to the provider defaults, or true.",
 				Optional:    true,
 				Computed:    true,
-				Default:     booldefault.StaticBool(true),
 			},
 			"crontab": schema.StringAttribute{
-				Description: "The crontab.",
-				Required:    true,
+				Description: "The crontab, a 5-field expression or a macro like `@hourly`. Defaults to the crontab in the provider defaults.",
+				Optional:    true,
+				Computed:    true,
+				CustomType:  crontab.Type{},
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The timezone of the times in `next_runs`, like `Europe/Amsterdam`. Defaults to UTC. The Folge API has no timezone setting, so the server runs the crontab in UTC regardless.",
+				Optional:    true,
+				Validators: []validator.String{
+					crontab.TimezoneValidator(),
+				},
+			},
+			"crontab_description": schema.StringAttribute{
+				Description: "A human-readable description of the crontab, in UTC like the server runs it.",
+				Computed:    true,
+			},
+			"next_runs": schema.ListAttribute{
+				Description: "The next runs of the check, refreshed on every read.",
+				Computed:    true,
+				ElementType: types.StringType,
 			},
 			"status_code": schema.Int64Attribute{
 				Description: "The expected HTTP status code.",
@@ -81,12 +113,83 @@ func (r *checkHttpStatusResource) Schema(_ context.Context, _ resource.SchemaReq
 }
 
 // Configure adds the provider configured client to the data source.
-func (r *checkHttpStatusResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (r *checkHttpStatusResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = utils.GetClient(req.ProviderData)
+	data, diags := utils.GetProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = data.Client
+	r.capabilities = data.Capabilities
+	r.policy = data.Policy
+	r.defaults = data.Defaults
+	r.cache = data.Cache
+}
+
+// ModifyPlan applies the provider defaults, verifies the planned check is
+// supported by the server and enforces the provider policy.
+func (r *checkHttpStatusResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.defaults.Apply(ctx, req.Config, &resp.Plan)...)
+	resp.Diagnostics.Append(crontab.PlanSchedule(ctx, req.State, &resp.Plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan CheckHttpStatusModel
+	diags := resp.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.capabilities.RequireCheckType("http-status")...)
+	resp.Diagnostics.Append(r.capabilities.RequireField(
+		path.Root("crontab"), "HttpStatusCheckTypedRequest", "crontab")...)
+	// Checks are enabled by default, so only disabling one requires support
+	if !plan.Enabled.IsUnknown() && !plan.Enabled.ValueBool() {
+		resp.Diagnostics.Append(r.capabilities.RequireField(
+			path.Root("enabled"), "HttpStatusCheckTypedRequest", "enabled")...)
+	}
+
+	resp.Diagnostics.Append(r.policy.ValidateLabel(path.Root("name"), plan.Name)...)
+	resp.Diagnostics.Append(r.policy.ValidateCrontab(path.Root("crontab"), plan.Crontab.StringValue)...)
+	resp.Diagnostics.Append(r.policy.ValidateEnabled(path.Root("enabled"), plan.Enabled)...)
+
+	// The number of checks only changes when a check is added to a datasource
+	if !r.policy.HasCheckCount() || r.client == nil {
+		return
+	}
+	if plan.ApplicationID.IsUnknown() || plan.DataSourceID.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.Int64
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("datasource_id"), &prior)...)
+		if resp.Diagnostics.HasError() || prior.Equal(plan.DataSourceID) {
+			return
+		}
+	}
+
+	dsId := utils.AsInt(plan.DataSourceID)
+	count, err := r.cache.ReserveCheck(ctx, r.client, utils.AsInt(plan.ApplicationID), dsId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list checks of datasource %d: %s", dsId, err.Error()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.policy.ValidateCheckCount(path.Root("datasource_id"), dsId, count)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -125,6 +228,7 @@ func (r *checkHttpStatusResource) Create(ctx context.Context, req resource.Creat
 	check_http_status := content.JSON201
 
 	// Map response body to schema and populate Computed attribute values
+	sent := plan.Crontab
 	if err := plan.fromRemote(*check_http_status, appId, dsId); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating check_http_status",
@@ -132,13 +236,7 @@ func (r *checkHttpStatusResource) Create(ctx context.Context, req resource.Creat
 		)
 		return
 	}
-
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(utils.SetWritten(ctx, &resp.State, plan, sent.StringValue, plan.Crontab.StringValue)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -155,6 +253,12 @@ func (r *checkHttpStatusResource) Read(ctx context.Context, req resource.ReadReq
 	appId := utils.AsInt(state.ApplicationID)
 	dsId := utils.AsInt(state.DataSourceID)
 	content, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	if utils.IsNotFound(content, err) {
+		reason := utils.MissingParentReason(ctx, r.client, appId, dsId)
+		resp.Diagnostics.Append(utils.NotFoundWarning("check_http_status", id, reason))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if d := utils.CheckGetError("check_http_status", id, content, err); d != nil {
 		resp.Diagnostics.Append(d)
 		return
@@ -162,7 +266,8 @@ func (r *checkHttpStatusResource) Read(ctx context.Context, req resource.ReadReq
 
 	check := *content.JSON200
 
-	// Overwrite items with refreshed state
+	// Overwrite items with refreshed state, including the upcoming runs
+	state.NextRuns = types.ListUnknown(types.StringType)
 	if err := state.fromRemote(check, appId, dsId); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Check",
@@ -216,6 +321,7 @@ func (r *checkHttpStatusResource) Update(ctx context.Context, req resource.Updat
 	check_http_status := *content.JSON200
 
 	// Map response body to schema and populate Computed attribute values
+	sent := plan.Crontab
 	if err := plan.fromRemote(check_http_status, appId, dsId); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating check_http_status",
@@ -223,12 +329,7 @@ func (r *checkHttpStatusResource) Update(ctx context.Context, req resource.Updat
 		)
 		return
 	}
-
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(utils.SetWritten(ctx, &resp.State, plan, sent.StringValue, plan.Crontab.StringValue)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -242,7 +343,9 @@ func (r *checkHttpStatusResource) Delete(ctx context.Context, req resource.Delet
 	}
 
 	id := utils.AsInt(state.ID)
-	content, err := r.client.ApplicationsDestroyWithResponse(ctx, id)
+	appId := utils.AsInt(state.ApplicationID)
+	dsId := utils.AsInt(state.DataSourceID)
+	content, err := r.client.ApplicationsDataSourcesChecksDestroyWithResponse(ctx, appId, dsId, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting check_http_status",
@@ -250,7 +353,8 @@ func (r *checkHttpStatusResource) Delete(ctx context.Context, req resource.Delet
 		)
 		return
 	}
-	if content.StatusCode() != http.StatusNoContent {
+	// A 404 means the check_http_status is already gone, which is fine
+	if !utils.IsDeleted(content) {
 		resp.Diagnostics.AddError(
 			"Error deleting check_http_status",
 			fmt.Sprintf(
@@ -260,9 +364,45 @@ func (r *checkHttpStatusResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	d := utils.ConfirmDeleted(ctx, "check_http_status", id, func() (utils.ApiResponse, error) {
+		return r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	})
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
 }
 
 func (r *checkHttpStatusResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parents, selector, err := utils.SplitImportID(req.ID, "application_id", "datasource_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	appId, dsId := parents[0], parents[1]
+
+	// The check is either imported by ID or label:<label>
+	id, err := utils.ResolveCheckID(ctx, r.client, appId, dsId, selector)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing check_http_status", err.Error())
+		return
+	}
+
+	// Verify the check exists and is managed by this resource type
+	content, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	if d := utils.CheckGetError("check_http_status", id, content, err); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+	if err := utils.CheckType(*content.JSON200, "http-status"); err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing check_http_status",
+			fmt.Sprintf("Could not import check %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), int64(appId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("datasource_id"), int64(dsId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
 }
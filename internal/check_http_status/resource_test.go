@@ -0,0 +1,215 @@
+package checkhttpstatus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/testutils"
+)
+
+// setup creates a check on the mock backend and returns the state for it.
+func setup(t *testing.T) (folge.ClientWithResponsesInterface, *checkHttpStatusResource, schema.Schema, CheckHttpStatusModel) {
+	client := testutils.NewClient(t)
+	r := &checkHttpStatusResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+
+	model := CheckHttpStatusModel{
+		ApplicationID: types.Int64Value(int64(appId)),
+		DataSourceID:  types.Int64Value(int64(dsId)),
+		Name:          types.StringValue("Status"),
+		StatusCode:    types.Int64Value(200),
+		Crontab:       crontab.NewValue("*/5 * * * *"),
+		Enabled:       types.BoolValue(true),
+		NextRuns:      types.ListNull(types.StringType),
+	}
+	id := testutils.CreateCheck(t, client, appId, dsId, model.createRequest())
+	model.ID = types.Int64Value(int64(id))
+
+	return client, r, s, model
+}
+
+func TestReadRemoved(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.False(t, resp.State.Raw.IsNull())
+
+	_, err := client.ApplicationsDataSourcesChecksDestroyWithResponse(ctx,
+		int(model.ApplicationID.ValueInt64()), int(model.DataSourceID.ValueInt64()), int(model.ID.ValueInt64()))
+	require.NoError(t, err)
+
+	resp = testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+}
+
+func TestReadParentRemoved(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+
+	_, err := client.ApplicationsDestroyWithResponse(ctx, int(model.ApplicationID.ValueInt64()))
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+	require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "application")
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	resp := testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	check, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(model.ID.ValueInt64()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, check.StatusCode())
+
+	// The application and datasource sharing the ID of the check are untouched
+	app, err := client.ApplicationsRetrieveWithResponse(ctx, appId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, app.StatusCode())
+	ds, err := client.ApplicationsDataSourcesRetrieveWithResponse(ctx, appId, dsId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, ds.StatusCode())
+
+	// Deleting a check which is already gone succeeds
+	resp = testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func TestReadDrift(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Empty(t, testutils.ConfigDiff(t, s, resp.State, model))
+
+	changed := model
+	changed.Name = types.StringValue("Health")
+	changed.StatusCode = types.Int64Value(204)
+	changed.Enabled = types.BoolValue(false)
+	changed.Crontab = crontab.NewValue("@hourly")
+	_, err := client.ApplicationsDataSourcesChecksUpdateWithResponse(ctx, appId, dsId, int(model.ID.ValueInt64()), changed.toUpdateInput())
+	require.NoError(t, err)
+
+	resp = testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckHttpStatusModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "Health", state.Name.ValueString())
+	assert.Equal(t, int64(204), state.StatusCode.ValueInt64())
+	assert.False(t, state.Enabled.ValueBool())
+	assert.Equal(t, "@hourly", state.Crontab.ValueString())
+
+	// The unchanged configuration shows the drift in the plan
+	assert.Equal(t, []string{"crontab", "enabled", "name", "status_code"}, testutils.ConfigDiff(t, s, resp.State, model))
+}
+
+func TestCreateCrontab(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	model.ID = types.Int64Unknown()
+	model.Crontab = crontab.NewValue("0 9 * * 1-5")
+	model.Timezone = types.StringValue("Europe/Amsterdam")
+	model.CrontabDescription = types.StringUnknown()
+	model.NextRuns = types.ListUnknown(types.StringType)
+	resp := testutils.Create(t, r, s, model)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckHttpStatusModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "0 9 * * 1-5", state.Crontab.ValueString())
+	assert.Equal(t, "Europe/Amsterdam", state.Timezone.ValueString())
+	assert.Equal(t, "At 09:00 on Monday through Friday (UTC)", state.CrontabDescription.ValueString())
+	assert.Len(t, state.NextRuns.Elements(), crontab.NextRunsCount)
+
+	content, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(state.ID.ValueInt64()))
+	require.NoError(t, err)
+	check, err := content.JSON200.AsHttpStatusCheckTyped()
+	require.NoError(t, err)
+	require.NotNil(t, check.Crontab)
+	assert.Equal(t, "0 9 * * 1-5", *check.Crontab)
+}
+
+func TestCreateCrontabDropped(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClientWithoutCrontab(t)
+	r := &checkHttpStatusResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+	model := CheckHttpStatusModel{
+		ID:                 types.Int64Unknown(),
+		ApplicationID:      types.Int64Value(int64(appId)),
+		DataSourceID:       types.Int64Value(int64(dsId)),
+		Name:               types.StringValue("Status"),
+		StatusCode:         types.Int64Value(200),
+		Crontab:            crontab.NewValue("*/5 * * * *"),
+		Enabled:            types.BoolValue(true),
+		CrontabDescription: types.StringUnknown(),
+		NextRuns:           types.ListUnknown(types.StringType),
+	}
+
+	// The check is kept in the state, so it isn't created twice
+	resp := testutils.Create(t, r, s, model)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Crontab not supported", resp.Diagnostics[0].Summary())
+
+	var state CheckHttpStatusModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.False(t, state.ID.IsNull())
+	assert.True(t, state.Crontab.IsNull())
+}
+
+func TestReadWrongType(t *testing.T) {
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	check := folge.Check{}
+	require.NoError(t, check.FromJsonDataCheckTyped(folge.JsonDataCheckTyped{
+		Label:    "Healthy",
+		Path:     "$.healthy",
+		Datatype: folge.Bool,
+		Operator: folge.Eq,
+		Value:    "true",
+	}))
+	model.ID = types.Int64Value(int64(testutils.CreateCheck(t, client, appId, dsId, check)))
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(),
+		`the check is of type "json-value" instead of "http-status", use the folge_check_json_property resource for it`)
+}
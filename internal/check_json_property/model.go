@@ -1,29 +1,139 @@
 package checkjsonproperty
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
+	"github.com/labd/terraform-provider-folge/internal/crontab"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/jsoncheck"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/timestamp"
+	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
 type CheckJsonPropertyModel struct {
-	ID            types.Int64  `tfsdk:"id"`
-	ApplicationID types.Int64  `tfsdk:"application_id"`
-	DataSourceID  types.Int64  `tfsdk:"datasource_id"`
-	Name          types.String `tfsdk:"name"`
-	Crontab       types.String `tfsdk:"crontab"`
-	Enabled       types.Bool   `tfsdk:"enabled"`
-	Path          types.String `tfsdk:"path"`
-	DataType      types.String `tfsdk:"datatype"`
-	Operator      types.String `tfsdk:"operator"`
-	ValueBoolean  types.Bool   `tfsdk:"value_bool"`
-	ValueString   types.String `tfsdk:"value_string"`
-	ValueInt      types.Int64  `tfsdk:"value_int"`
-	ValueDateTime types.String `tfsdk:"value_datetime"`
+	ID            types.Int64     `tfsdk:"id"`
+	ApplicationID types.Int64     `tfsdk:"application_id"`
+	DataSourceID  types.Int64     `tfsdk:"datasource_id"`
+	Name          types.String    `tfsdk:"name"`
+	Crontab       crontab.Value   `tfsdk:"crontab"`
+	Timezone      types.String    `tfsdk:"timezone"`
+	Enabled       types.Bool      `tfsdk:"enabled"`
+	Path          jsonpath.Value  `tfsdk:"path"`
+	DataType      types.String    `tfsdk:"datatype"`
+	Operator      types.String    `tfsdk:"operator"`
+	Value         types.Dynamic   `tfsdk:"value"`
+	ValueBoolean  types.Bool      `tfsdk:"value_bool"`
+	ValueString   types.String    `tfsdk:"value_string"`
+	ValueInt      types.Int64     `tfsdk:"value_int"`
+	ValueDateTime timestamp.Value `tfsdk:"value_datetime"`
+
+	CrontabDescription types.String `tfsdk:"crontab_description"`
+	NextRuns           types.List   `tfsdk:"next_runs"`
+}
+
+// valueAttribute is an attribute holding the value to compare against.
+type valueAttribute struct {
+	name     string
+	datatype folge.DatatypeEnum
+	value    attr.Value
+}
+
+// valueAttributes returns the value attributes with the datatype they are
+// used for.
+func (m *CheckJsonPropertyModel) valueAttributes() []valueAttribute {
+	return []valueAttribute{
+		{name: "value_bool", datatype: folge.Bool, value: m.ValueBoolean},
+		{name: "value_int", datatype: folge.Int, value: m.ValueInt},
+		{name: "value_string", datatype: folge.Str, value: m.ValueString},
+		{name: "value_datetime", datatype: folge.Datetime, value: m.ValueDateTime},
+	}
+}
+
+// usesValueAttributes reports whether one of the deprecated value attributes
+// is set instead of value.
+func (m *CheckJsonPropertyModel) usesValueAttributes() bool {
+	for _, attribute := range m.valueAttributes() {
+		if !attribute.value.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyValue returns the deprecated value attribute matching the datatype as
+// a value for the value attribute. It is null when that attribute isn't set.
+func (m *CheckJsonPropertyModel) legacyValue() types.Dynamic {
+	if m.DataType.IsUnknown() {
+		if m.usesValueAttributes() {
+			return types.DynamicUnknown()
+		}
+		return types.DynamicNull()
+	}
+
+	datatype := folge.DatatypeEnum(m.DataType.ValueString())
+	for _, attribute := range m.valueAttributes() {
+		switch {
+		case attribute.datatype != datatype:
+			continue
+		case attribute.value.IsUnknown():
+			return types.DynamicUnknown()
+		case attribute.value.IsNull():
+			return types.DynamicNull()
+		}
+
+		switch v := attribute.value.(type) {
+		case types.Int64:
+			return types.DynamicValue(types.NumberValue(new(big.Float).SetInt64(v.ValueInt64())))
+		case timestamp.Value:
+			return types.DynamicValue(v.StringValue)
+		default:
+			return types.DynamicValue(attribute.value)
+		}
+	}
+	return types.DynamicNull()
+}
+
+// setLegacyValue sets the deprecated value attribute matching the datatype
+// from the value. Datatypes without a deprecated attribute are ignored.
+func (m *CheckJsonPropertyModel) setLegacyValue(datatype folge.DatatypeEnum, value types.Dynamic) error {
+	switch datatype {
+	case folge.Bool:
+		v, ok := value.UnderlyingValue().(basetypes.BoolValue)
+		if !ok {
+			return fmt.Errorf("invalid bool value %s", value)
+		}
+		m.ValueBoolean = v
+	case folge.Int:
+		v, ok := value.UnderlyingValue().(basetypes.NumberValue)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			return fmt.Errorf("invalid int value %s", value)
+		}
+		i, accuracy := v.ValueBigFloat().Int64()
+		if accuracy != big.Exact {
+			return fmt.Errorf("invalid int value %s, it is not a whole number", value)
+		}
+		m.ValueInt = types.Int64Value(i)
+	case folge.Str:
+		v, ok := value.UnderlyingValue().(basetypes.StringValue)
+		if !ok {
+			return fmt.Errorf("invalid string value %s", value)
+		}
+		m.ValueString = v
+	case folge.Datetime:
+		v, ok := value.UnderlyingValue().(basetypes.StringValue)
+		if !ok {
+			return fmt.Errorf("invalid datetime value %s", value)
+		}
+		m.ValueDateTime = timestamp.Value{StringValue: v}
+	}
+	return nil
 }
 
 func (m *CheckJsonPropertyModel) toCreateInput() folge.ApplicationsDataSourcesChecksCreateJSONRequestBody {
@@ -38,6 +148,7 @@ func (m *CheckJsonPropertyModel) toUpdateInput() folge.ApplicationsDataSourcesCh
 
 func (m *CheckJsonPropertyModel) createRequest() folge.Check {
 	data := folge.JsonDataCheckTyped{
+		Crontab:  crontab.ToRemote(m.Crontab),
 		Enabled:  m.Enabled.ValueBoolPointer(),
 		Label:    m.Name.ValueString(),
 		Datatype: folge.DatatypeEnum(m.DataType.ValueString()),
@@ -45,32 +156,16 @@ func (m *CheckJsonPropertyModel) createRequest() folge.Check {
 		Path:     m.Path.ValueString(),
 	}
 
-	switch data.Datatype {
-	case folge.Bool:
-		val, err := json.Marshal(m.ValueBoolean.ValueBool())
-		if err != nil {
-			panic(err)
-		}
-		data.Value = string(val)
-	case folge.Int:
-		val, err := json.Marshal(m.ValueInt.ValueInt64())
-		if err != nil {
-			panic(err)
-		}
-		data.Value = string(val)
-	case folge.Str:
-		val, err := json.Marshal(m.ValueString.ValueString())
-		if err != nil {
-			panic(err)
-		}
-		data.Value = string(val)
-	case folge.Datetime:
-		val, err := json.Marshal(m.ValueDateTime.ValueString())
-		if err != nil {
-			panic(err)
-		}
-		data.Value = string(val)
+	// The deprecated value attributes are only used when value isn't set
+	value := m.Value
+	if value.IsNull() {
+		value = m.legacyValue()
 	}
+	val, err := jsoncheck.ValueToRemote(data.Datatype, value)
+	if err != nil {
+		panic(err)
+	}
+	data.Value = val
 
 	req := folge.Check{}
 	if err := req.FromJsonDataCheckTyped(data); err != nil {
@@ -80,62 +175,45 @@ func (m *CheckJsonPropertyModel) createRequest() folge.Check {
 }
 
 func (m *CheckJsonPropertyModel) fromRemote(i folge.Check, applicationId int, datasourceId int) error {
-	t, err := i.ValueByDiscriminator()
+	if err := utils.CheckType(i, "json-value"); err != nil {
+		return err
+	}
+	d, err := i.AsJsonDataCheckTyped()
 	if err != nil {
 		return err
 	}
 
-	switch d := t.(type) {
-	case folge.JsonDataCheckTyped:
-		m.ID = types.Int64Value(int64(*d.Id))
-		m.Name = types.StringValue(d.Label)
-		m.ApplicationID = types.Int64Value(int64(applicationId))
-		m.DataSourceID = types.Int64Value(int64(datasourceId))
-		m.Enabled = types.BoolPointerValue(d.Enabled)
-
-		m.Operator = types.StringValue(string(d.Operator))
-		m.Path = types.StringValue(d.Path)
-		m.DataType = types.StringValue(string(d.Datatype))
-
-		switch d.Datatype {
-		case folge.Bool:
-			var dst bool
-			err := json.Unmarshal([]byte(d.Value), &dst)
-			if err != nil {
-				return fmt.Errorf("Invalid bool value: %s", err)
-			}
-			m.ValueBoolean = types.BoolValue(dst)
-
-		case folge.Int:
-			var dst int64
-			err := json.Unmarshal([]byte(d.Value), &dst)
-			if err != nil {
-				return fmt.Errorf("Invalid int value: %s", err)
-			}
-			m.ValueInt = types.Int64Value(dst)
-
-		case folge.Str:
-			var dst string
-			err := json.Unmarshal([]byte(d.Value), &dst)
-			if err != nil {
-				return fmt.Errorf("Invalid datetime value: %s", err)
-			}
-			m.ValueString = types.StringValue(dst)
-
-		case folge.Datetime:
-			var dst string
-			err := json.Unmarshal([]byte(d.Value), &dst)
-			if err != nil {
-				return fmt.Errorf("Invalid datetime value: %s", err)
-			}
-			m.ValueDateTime = types.StringValue(dst)
+	m.ID = types.Int64Value(int64(*d.Id))
+	m.Name = types.StringValue(d.Label)
+	m.ApplicationID = types.Int64Value(int64(applicationId))
+	m.DataSourceID = types.Int64Value(int64(datasourceId))
+	m.Crontab = crontab.FromRemote(d.Crontab, m.Crontab)
+	m.CrontabDescription = crontab.DescriptionValue(m.Crontab)
+	if m.NextRuns.IsUnknown() {
+		m.NextRuns = crontab.NextRunsValue(m.Crontab, m.Timezone, time.Now())
+	}
+	m.Enabled = utils.EnabledValue(d.Enabled)
 
-		default:
-			return fmt.Errorf("unknown datatype: %s", d.Datatype)
-		}
+	m.Operator = types.StringValue(string(d.Operator))
+	m.Path = jsonpath.NewValue(d.Path)
+	m.DataType = types.StringValue(string(d.Datatype))
+
+	value, err := jsoncheck.ValueFromRemote(d.Datatype, d.Value, m.Value)
+	if err != nil {
+		return err
+	}
 
-	default:
-		return errors.New("unknown data source type")
+	// Configurations still using the deprecated value attributes keep
+	// using them, next to value. Only the one matching the datatype is set,
+	// so a changed datatype shows up as a diff on the value as well.
+	legacy := m.usesValueAttributes()
+	m.Value = value
+	m.ValueBoolean = types.BoolNull()
+	m.ValueInt = types.Int64Null()
+	m.ValueString = types.StringNull()
+	m.ValueDateTime = timestamp.NewNull()
+	if !legacy {
+		return nil
 	}
-	return nil
+	return m.setLegacyValue(d.Datatype, value)
 }
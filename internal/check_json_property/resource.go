@@ -9,20 +9,29 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
+	"github.com/labd/terraform-provider-folge/internal/crontab"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/jsoncheck"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/policy"
+	"github.com/labd/terraform-provider-folge/internal/timestamp"
 	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &checkJsonPropertyResource{}
-	_ resource.ResourceWithConfigure   = &checkJsonPropertyResource{}
-	_ resource.ResourceWithImportState = &checkJsonPropertyResource{}
+	_ resource.Resource                   = &checkJsonPropertyResource{}
+	_ resource.ResourceWithConfigure      = &checkJsonPropertyResource{}
+	_ resource.ResourceWithValidateConfig = &checkJsonPropertyResource{}
+	_ resource.ResourceWithModifyPlan     = &checkJsonPropertyResource{}
+	_ resource.ResourceWithImportState    = &checkJsonPropertyResource{}
+	_ resource.ResourceWithUpgradeState   = &checkJsonPropertyResource{}
 )
 
 // NewCheckJsonPropertyResource is a helper function to simplify the provider implementation.
@@ -30,9 +39,16 @@ func NewCheckJsonPropertyResource() resource.Resource {
 	return &checkJsonPropertyResource{}
 }
 
+// valueDeprecation is the deprecation message of the typed value attributes.
+const valueDeprecation = "Use value instead, which takes a value of the type matching the datatype. This attribute will be removed in the next release."
+
 // checkJsonPropertyResource is the resource implementation.
 type checkJsonPropertyResource struct {
-	client folge.ClientWithResponsesInterface
+	client       folge.ClientWithResponsesInterface
+	capabilities *capabilities.Capabilities
+	policy       *policy.Policy
+	defaults     *utils.CheckDefaults
+	cache        *utils.Cache
 }
 
 // Metadata returns the data source type name.
@@ -44,6 +60,7 @@ func (r *checkJsonPropertyResource) Metadata(_ context.Context, req resource.Met
 func (r *checkJsonPropertyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "The check_json_property",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "The ID of the check_json_property",
@@ -53,72 +70,287 @@ func (r *checkJsonPropertyResource) Schema(_ context.Context, _ resource.SchemaR
 				},
 			},
 			"application_id": schema.Int64Attribute{
-				Description: "The ID of the applicaton",
+				Description: "The ID of the applicaton. Changing it replaces the check.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"datasource_id": schema.Int64Attribute{
-				Description: "The ID of the datasource",
+				Description: "The ID of the datasource. Changing it replaces the check.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name.",
 				Required:    true,
 			},
 			"enabled": schema.BoolAttribute{
-				Description: "Whether the check is enabled.",
+				Description: "Whether the check is enabled. Defaults to the provider defaults, or true.",
 				Optional:    true,
 				Computed:    true,
-				Default:     booldefault.StaticBool(true),
 			},
 			"crontab": schema.StringAttribute{
-				Description: "The crontab.",
-				Required:    true,
+				Description: "The crontab, a 5-field expression or a macro like `@hourly`. Defaults to the crontab in the provider defaults.",
+				Optional:    true,
+				Computed:    true,
+				CustomType:  crontab.Type{},
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The timezone of the times in `next_runs`, like `Europe/Amsterdam`. Defaults to UTC. The Folge API has no timezone setting, so the server runs the crontab in UTC regardless.",
+				Optional:    true,
+				Validators: []validator.String{
+					crontab.TimezoneValidator(),
+				},
+			},
+			"crontab_description": schema.StringAttribute{
+				Description: "A human-readable description of the crontab, in UTC like the server runs it.",
+				Computed:    true,
+			},
+			"next_runs": schema.ListAttribute{
+				Description: "The next runs of the check, refreshed on every read.",
+				Computed:    true,
+				ElementType: types.StringType,
 			},
 			"path": schema.StringAttribute{
-				Description: "The json path to check.",
+				Description: "The json path to check, like `$.status`, `items[0].name` or `$['key.with.dots']`. The leading `$.` is optional.",
 				Required:    true,
+				CustomType:  jsonpath.Type{},
 			},
 			"datatype": schema.StringAttribute{
-				Description: "The data type of the property.",
+				Description: "The data type of the property. The `null` datatype takes no value, and checks whether the property is null (`eq`), not null (`neq`), present (`exists`) or absent (`not_exists`). `float` and `null` require a Folge server supporting them.",
 				Required:    true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("str", "int", "bool", "datetime"),
+					stringvalidator.OneOf(jsoncheck.Datatypes()...),
 				},
 			},
 			"operator": schema.StringAttribute{
-				Description: "The operator to use for the check.",
+				Description: "The operator to use for the check. `gt` and `lt` can only be used with the `int`, `float` and `datetime` datatypes, `exists` and `not_exists` only with the `null` datatype.",
 				Required:    true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("eq", "neq", "gt", "lt"),
+					stringvalidator.OneOf(jsoncheck.Operators()...),
 				},
 			},
-			"value_bool": schema.BoolAttribute{
-				Description: "The value to compare against for a boolean property.",
+			"value": schema.DynamicAttribute{
+				Description: "The value to compare against. Its type must match the datatype: a bool for `bool`, a whole number for `int`, a number for `float`, a string for `str` and an RFC 3339 timestamp string like `2024-01-01T12:00:00Z` for `datetime`. Must not be set for `null`. Set from the deprecated value attributes when they are used instead.",
 				Optional:    true,
+				Computed:    true,
+			},
+			"value_bool": schema.BoolAttribute{
+				Description:        "The value to compare against for a boolean property.",
+				Optional:           true,
+				DeprecationMessage: valueDeprecation,
 			},
 			"value_int": schema.Int64Attribute{
-				Description: "The value to compare against for an integer property.",
-				Optional:    true,
+				Description:        "The value to compare against for an integer property.",
+				Optional:           true,
+				DeprecationMessage: valueDeprecation,
 			},
 			"value_string": schema.StringAttribute{
-				Description: "The value to compare against for a string property.",
-				Optional:    true,
+				Description:        "The value to compare against for a string property.",
+				Optional:           true,
+				DeprecationMessage: valueDeprecation,
 			},
 			"value_datetime": schema.StringAttribute{
-				Description: "The value to compare against for a datetime property.",
-				Optional:    true,
+				Description:        "The value to compare against for a datetime property, as an RFC 3339 timestamp like `2024-01-01T12:00:00Z`.",
+				Optional:           true,
+				CustomType:         timestamp.Type{},
+				DeprecationMessage: valueDeprecation,
 			},
 		},
 	}
 }
 
 // Configure adds the provider configured client to the data source.
-func (r *checkJsonPropertyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (r *checkJsonPropertyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = utils.GetClient(req.ProviderData)
+	data, diags := utils.GetProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = data.Client
+	r.capabilities = data.Capabilities
+	r.policy = data.Policy
+	r.defaults = data.Defaults
+	r.cache = data.Cache
+}
+
+// ValidateConfig checks that the operator can be used with the datatype, and
+// that the value is set with a type matching the datatype. The deprecated
+// value attributes may be used instead, as long as only the one matching the
+// datatype is set.
+func (r *checkJsonPropertyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CheckJsonPropertyModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Value.IsNull() {
+		for _, attribute := range config.valueAttributes() {
+			if !attribute.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute.name),
+					"Conflicting value",
+					fmt.Sprintf("%s can't be set together with value.", attribute.name),
+				)
+			}
+		}
+	}
+
+	// The matching type is only known once the datatype is known
+	if config.DataType.IsNull() || config.DataType.IsUnknown() {
+		return
+	}
+	datatype := folge.DatatypeEnum(config.DataType.ValueString())
+
+	if !config.Operator.IsNull() && !config.Operator.IsUnknown() {
+		operator := folge.OperatorEnum(config.Operator.ValueString())
+		resp.Diagnostics.Append(jsoncheck.ValidateOperator(path.Root("operator"), operator, datatype)...)
+	}
+
+	if !jsoncheck.KnownDatatype(datatype) {
+		return
+	}
+	if !config.Value.IsNull() || !jsoncheck.NeedsValue(datatype) {
+		resp.Diagnostics.Append(jsoncheck.ValidateValue(path.Root("value"), datatype, config.Value)...)
+	}
+	if !config.Value.IsNull() {
+		return
+	}
+
+	if !config.usesValueAttributes() {
+		if jsoncheck.NeedsValue(datatype) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("value"),
+				"Missing value",
+				fmt.Sprintf("value must be set when the datatype is %q.", datatype),
+			)
+		}
+		return
+	}
+
+	var expected string
+	for _, attribute := range config.valueAttributes() {
+		if attribute.datatype == datatype {
+			expected = attribute.name
+		}
+	}
+
+	for _, attribute := range config.valueAttributes() {
+		switch {
+		case attribute.name == expected && attribute.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Missing value",
+				fmt.Sprintf("%s must be set when the datatype is %q, or use value instead.", attribute.name, datatype),
+			)
+		case attribute.name != expected && !attribute.value.IsNull() && !attribute.value.IsUnknown() && !jsoncheck.NeedsValue(datatype):
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Unexpected value",
+				fmt.Sprintf("%s can't be set when the datatype is %q.", attribute.name, datatype),
+			)
+		case attribute.name != expected && !attribute.value.IsNull() && !attribute.value.IsUnknown():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Unexpected value",
+				fmt.Sprintf("%s can't be set when the datatype is %q, set value instead.", attribute.name, datatype),
+			)
+		}
+	}
+}
+
+// ModifyPlan applies the provider defaults, verifies the planned check is
+// supported by the server and enforces the provider policy.
+func (r *checkJsonPropertyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.defaults.Apply(ctx, req.Config, &resp.Plan)...)
+	resp.Diagnostics.Append(crontab.PlanSchedule(ctx, req.State, &resp.Plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan CheckJsonPropertyModel
+	diags := resp.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// value is computed from the deprecated value attributes when the
+	// configuration still uses them, like the state after a read
+	var config CheckJsonPropertyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Value.IsNull() {
+		plan.Value = config.legacyValue()
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), plan.Value)...)
+	}
+
+	resp.Diagnostics.Append(r.capabilities.RequireCheckType("json-value")...)
+	resp.Diagnostics.Append(r.capabilities.RequireField(
+		path.Root("crontab"), "JsonDataCheckTypedRequest", "crontab")...)
+	// Checks are enabled by default, so only disabling one requires support
+	if !plan.Enabled.IsUnknown() && !plan.Enabled.ValueBool() {
+		resp.Diagnostics.Append(r.capabilities.RequireField(
+			path.Root("enabled"), "JsonDataCheckTypedRequest", "enabled")...)
+	}
+	if !plan.DataType.IsUnknown() {
+		resp.Diagnostics.Append(r.capabilities.RequireEnumValue(
+			path.Root("datatype"), "DatatypeEnum", plan.DataType.ValueString())...)
+		// Values unknown during validation are checked once they are known
+		resp.Diagnostics.Append(jsoncheck.ValidateValue(
+			path.Root("value"), folge.DatatypeEnum(plan.DataType.ValueString()), plan.Value)...)
+	}
+	if !plan.Operator.IsUnknown() {
+		resp.Diagnostics.Append(r.capabilities.RequireEnumValue(
+			path.Root("operator"), "OperatorEnum", plan.Operator.ValueString())...)
+	}
+
+	resp.Diagnostics.Append(r.policy.ValidateLabel(path.Root("name"), plan.Name)...)
+	resp.Diagnostics.Append(r.policy.ValidateCrontab(path.Root("crontab"), plan.Crontab.StringValue)...)
+	resp.Diagnostics.Append(r.policy.ValidateEnabled(path.Root("enabled"), plan.Enabled)...)
+
+	// The number of checks only changes when a check is added to a datasource
+	if !r.policy.HasCheckCount() || r.client == nil {
+		return
+	}
+	if plan.ApplicationID.IsUnknown() || plan.DataSourceID.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.Int64
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("datasource_id"), &prior)...)
+		if resp.Diagnostics.HasError() || prior.Equal(plan.DataSourceID) {
+			return
+		}
+	}
+
+	dsId := utils.AsInt(plan.DataSourceID)
+	count, err := r.cache.ReserveCheck(ctx, r.client, utils.AsInt(plan.ApplicationID), dsId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list checks of datasource %d: %s", dsId, err.Error()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.policy.ValidateCheckCount(path.Root("datasource_id"), dsId, count)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -157,6 +389,7 @@ func (r *checkJsonPropertyResource) Create(ctx context.Context, req resource.Cre
 	check_json_property := content.JSON201
 
 	// Map response body to schema and populate Computed attribute values
+	sent := plan.Crontab
 	if err := plan.fromRemote(*check_json_property, appId, dsId); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating check_json_property",
@@ -164,13 +397,7 @@ func (r *checkJsonPropertyResource) Create(ctx context.Context, req resource.Cre
 		)
 		return
 	}
-
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(utils.SetWritten(ctx, &resp.State, plan, sent.StringValue, plan.Crontab.StringValue)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -187,6 +414,12 @@ func (r *checkJsonPropertyResource) Read(ctx context.Context, req resource.ReadR
 	appId := utils.AsInt(state.ApplicationID)
 	dsId := utils.AsInt(state.DataSourceID)
 	content, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	if utils.IsNotFound(content, err) {
+		reason := utils.MissingParentReason(ctx, r.client, appId, dsId)
+		resp.Diagnostics.Append(utils.NotFoundWarning("check_json_property", id, reason))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if d := utils.CheckGetError("check_json_property", id, content, err); d != nil {
 		resp.Diagnostics.Append(d)
 		return
@@ -194,7 +427,8 @@ func (r *checkJsonPropertyResource) Read(ctx context.Context, req resource.ReadR
 
 	check := *content.JSON200
 
-	// Overwrite items with refreshed state
+	// Overwrite items with refreshed state, including the upcoming runs
+	state.NextRuns = types.ListUnknown(types.StringType)
 	if err := state.fromRemote(check, appId, dsId); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Check",
@@ -248,6 +482,7 @@ func (r *checkJsonPropertyResource) Update(ctx context.Context, req resource.Upd
 	check_json_property := *content.JSON200
 
 	// Map response body to schema and populate Computed attribute values
+	sent := plan.Crontab
 	if err := plan.fromRemote(check_json_property, appId, dsId); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating check_json_property",
@@ -255,12 +490,7 @@ func (r *checkJsonPropertyResource) Update(ctx context.Context, req resource.Upd
 		)
 		return
 	}
-
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(utils.SetWritten(ctx, &resp.State, plan, sent.StringValue, plan.Crontab.StringValue)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -274,7 +504,9 @@ func (r *checkJsonPropertyResource) Delete(ctx context.Context, req resource.Del
 	}
 
 	id := utils.AsInt(state.ID)
-	content, err := r.client.ApplicationsDestroyWithResponse(ctx, id)
+	appId := utils.AsInt(state.ApplicationID)
+	dsId := utils.AsInt(state.DataSourceID)
+	content, err := r.client.ApplicationsDataSourcesChecksDestroyWithResponse(ctx, appId, dsId, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting check_json_property",
@@ -282,7 +514,8 @@ func (r *checkJsonPropertyResource) Delete(ctx context.Context, req resource.Del
 		)
 		return
 	}
-	if content.StatusCode() != http.StatusNoContent {
+	// A 404 means the check_json_property is already gone, which is fine
+	if !utils.IsDeleted(content) {
 		resp.Diagnostics.AddError(
 			"Error deleting check_json_property",
 			fmt.Sprintf(
@@ -292,9 +525,45 @@ func (r *checkJsonPropertyResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	d := utils.ConfirmDeleted(ctx, "check_json_property", id, func() (utils.ApiResponse, error) {
+		return r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	})
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
 }
 
 func (r *checkJsonPropertyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parents, selector, err := utils.SplitImportID(req.ID, "application_id", "datasource_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	appId, dsId := parents[0], parents[1]
+
+	// The check is either imported by ID or label:<label>
+	id, err := utils.ResolveCheckID(ctx, r.client, appId, dsId, selector)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing check_json_property", err.Error())
+		return
+	}
+
+	// Verify the check exists and is managed by this resource type
+	content, err := r.client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, id)
+	if d := utils.CheckGetError("check_json_property", id, content, err); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+	if err := utils.CheckType(*content.JSON200, "json-value"); err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing check_json_property",
+			fmt.Sprintf("Could not import check %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), int64(appId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("datasource_id"), int64(dsId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
 }
@@ -0,0 +1,428 @@
+package checkjsonproperty
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/mock"
+	"github.com/labd/terraform-provider-folge/internal/testutils"
+	"github.com/labd/terraform-provider-folge/internal/timestamp"
+)
+
+// setup creates a check on the mock backend and returns the state for it.
+func setup(t *testing.T) (folge.ClientWithResponsesInterface, *checkJsonPropertyResource, schema.Schema, CheckJsonPropertyModel) {
+	client := testutils.NewClient(t)
+	r := &checkJsonPropertyResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+
+	model := CheckJsonPropertyModel{
+		ApplicationID: types.Int64Value(int64(appId)),
+		DataSourceID:  types.Int64Value(int64(dsId)),
+		Name:          types.StringValue("Healthy"),
+		Crontab:       crontab.NewValue("*/5 * * * *"),
+		Enabled:       types.BoolValue(true),
+		Path:          jsonpath.NewValue("$.healthy"),
+		DataType:      types.StringValue("bool"),
+		Operator:      types.StringValue("eq"),
+		ValueBoolean:  types.BoolValue(true),
+		ValueInt:      types.Int64Null(),
+		ValueString:   types.StringNull(),
+		ValueDateTime: timestamp.NewNull(),
+		NextRuns:      types.ListNull(types.StringType),
+	}
+	id := testutils.CreateCheck(t, client, appId, dsId, model.createRequest())
+	model.ID = types.Int64Value(int64(id))
+
+	return client, r, s, model
+}
+
+func TestReadDrift(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+	id := int(model.ID.ValueInt64())
+
+	// Change the datatype outside of Terraform
+	changed := model
+	changed.Path = jsonpath.NewValue("$.instances")
+	changed.DataType = types.StringValue("int")
+	changed.Operator = types.StringValue("gt")
+	changed.ValueInt = types.Int64Value(2)
+	_, err := client.ApplicationsDataSourcesChecksUpdateWithResponse(ctx, appId, dsId, id, changed.toUpdateInput())
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckJsonPropertyModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "$.instances", state.Path.ValueString())
+	assert.Equal(t, "int", state.DataType.ValueString())
+	assert.Equal(t, "gt", state.Operator.ValueString())
+	assert.Equal(t, int64(2), state.ValueInt.ValueInt64())
+	assert.True(t, state.ValueBoolean.IsNull())
+
+	// The unchanged configuration shows the drift in the plan
+	assert.Equal(t, []string{"datatype", "operator", "path", "value_bool", "value_int"}, testutils.ConfigDiff(t, s, resp.State, model))
+}
+
+func TestReadRemoved(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	_, err := client.ApplicationsDataSourcesChecksDestroyWithResponse(ctx, appId, dsId, int(model.ID.ValueInt64()))
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+
+	resp := testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	check, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(model.ID.ValueInt64()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, check.StatusCode())
+
+	// The datasource of the check is untouched
+	ds, err := client.ApplicationsDataSourcesRetrieveWithResponse(ctx, appId, dsId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, ds.StatusCode())
+
+	// Deleting a check which is already gone succeeds
+	resp = testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func TestValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &checkJsonPropertyResource{}
+	s := testutils.Configure(t, r, testutils.NewClient(t))
+
+	validate := func(datatype types.String, modify func(m *CheckJsonPropertyModel)) *resource.ValidateConfigResponse {
+		model := CheckJsonPropertyModel{
+			ApplicationID: types.Int64Value(1),
+			DataSourceID:  types.Int64Value(1),
+			Name:          types.StringValue("Instances"),
+			Path:          jsonpath.NewValue("$.instances"),
+			DataType:      datatype,
+			Operator:      types.StringValue("gt"),
+			NextRuns:      types.ListNull(types.StringType),
+		}
+		modify(&model)
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: testutils.State(t, s, model).Raw},
+		}, resp)
+		return resp
+	}
+
+	resp := validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.ValueInt = types.Int64Value(2)
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// The value may only be known when applying
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.ValueInt = types.Int64Unknown()
+		m.ValueString = types.StringUnknown()
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "value must be set")
+
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.ValueInt = types.Int64Value(2)
+		m.ValueString = types.StringValue("2")
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "set value instead")
+
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.Value = types.DynamicValue(types.NumberValue(big.NewFloat(2)))
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.Value = types.DynamicValue(types.StringValue("2"))
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, `The value must be a whole number for the datatype "int": got a string.`, resp.Diagnostics[0].Detail())
+
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.Value = types.DynamicValue(types.NumberValue(big.NewFloat(2)))
+		m.ValueInt = types.Int64Value(2)
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "value_int can't be set together with value")
+
+	resp = validate(types.StringValue("int"), func(m *CheckJsonPropertyModel) {
+		m.Value = types.DynamicUnknown()
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate(types.StringUnknown(), func(m *CheckJsonPropertyModel) {
+		m.ValueString = types.StringValue("2")
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate(types.StringValue("float"), func(m *CheckJsonPropertyModel) {})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "value must be set")
+
+	resp = validate(types.StringValue("null"), func(m *CheckJsonPropertyModel) {
+		m.Operator = types.StringValue("exists")
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = validate(types.StringValue("null"), func(m *CheckJsonPropertyModel) {
+		m.Operator = types.StringValue("eq")
+		m.Value = types.DynamicValue(types.StringValue("null"))
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `value can't be set when the datatype is "null"`)
+
+	resp = validate(types.StringValue("null"), func(m *CheckJsonPropertyModel) {
+		m.Operator = types.StringValue("eq")
+		m.ValueInt = types.Int64Value(2)
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `value_int can't be set when the datatype is "null".`)
+}
+
+func TestCreateValue(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &checkJsonPropertyResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+
+	model := CheckJsonPropertyModel{
+		ID:                 types.Int64Unknown(),
+		ApplicationID:      types.Int64Value(int64(appId)),
+		DataSourceID:       types.Int64Value(int64(dsId)),
+		Name:               types.StringValue("Instances"),
+		Crontab:            crontab.NewValue("*/5 * * * *"),
+		Enabled:            types.BoolValue(true),
+		Path:               jsonpath.NewValue("$.instances"),
+		DataType:           types.StringValue("int"),
+		Operator:           types.StringValue("gt"),
+		Value:              types.DynamicValue(types.NumberValue(big.NewFloat(2))),
+		CrontabDescription: types.StringUnknown(),
+		NextRuns:           types.ListUnknown(types.StringType),
+	}
+	resp := testutils.Create(t, r, s, model)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckJsonPropertyModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.True(t, model.Value.Equal(state.Value))
+	assert.True(t, state.ValueInt.IsNull())
+
+	content, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(state.ID.ValueInt64()))
+	require.NoError(t, err)
+	check, err := content.JSON200.AsJsonDataCheckTyped()
+	require.NoError(t, err)
+	assert.Equal(t, "2", check.Value)
+}
+
+func TestUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	client, r, s, model := setup(t)
+	upgrader := r.UpgradeState(ctx)[0]
+	appId := int(model.ApplicationID.ValueInt64())
+	dsId := int(model.DataSourceID.ValueInt64())
+	id := int(model.ID.ValueInt64())
+
+	// A check created with value_int by an earlier version of the provider
+	model.Path = jsonpath.NewValue("$.instances")
+	model.DataType = types.StringValue("int")
+	model.Operator = types.StringValue("gt")
+	model.ValueBoolean = types.BoolNull()
+	model.ValueInt = types.Int64Value(2)
+	_, err := client.ApplicationsDataSourcesChecksUpdateWithResponse(ctx, appId, dsId, id, model.toUpdateInput())
+	require.NoError(t, err)
+
+	rawState := &tfprotov6.RawState{JSON: []byte(fmt.Sprintf(`{
+		"id": %d,
+		"application_id": %d,
+		"datasource_id": %d,
+		"name": "Status",
+		"enabled": true,
+		"crontab": "*/5 * * * *",
+		"path": "$.instances",
+		"datatype": "int",
+		"operator": "gt",
+		"value_bool": null,
+		"value_int": 2,
+		"value_string": null,
+		"value_datetime": null
+	}`, id, appId, dsId))}
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	priorRaw, err := rawState.UnmarshalWithOpts(priorType, tfprotov6.UnmarshalOpts{})
+	require.NoError(t, err)
+	priorState := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorRaw}
+
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: rawState, State: &priorState}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckJsonPropertyModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.True(t, types.DynamicValue(types.NumberValue(big.NewFloat(2))).Equal(state.Value), state.Value)
+	assert.Equal(t, int64(2), state.ValueInt.ValueInt64())
+	assert.Equal(t, "$.instances", state.Path.ValueString())
+	assert.Equal(t, "*/5 * * * *", state.Crontab.ValueString())
+	assert.Equal(t, "At every 5th minute (UTC)", state.CrontabDescription.ValueString())
+	assert.Len(t, state.NextRuns.Elements(), crontab.NextRunsCount)
+	assert.Equal(t, model.ID, state.ID)
+
+	// Refreshing keeps the deprecated attribute used by the configuration
+	read := testutils.Read(t, r, resp.State)
+	require.False(t, read.Diagnostics.HasError(), read.Diagnostics)
+	require.False(t, read.State.Get(ctx, &state).HasError())
+	assert.True(t, types.DynamicValue(types.NumberValue(big.NewFloat(2))).Equal(state.Value), state.Value)
+	assert.Equal(t, int64(2), state.ValueInt.ValueInt64())
+
+	// Planning the configuration which still uses value_int shows no diff
+	config := state
+	config.ID = types.Int64Null()
+	config.Value = types.DynamicNull()
+	config.CrontabDescription = types.StringNull()
+	config.NextRuns = types.ListNull(types.StringType)
+	plan := tfsdk.Plan{Schema: s, Raw: read.State.Raw}
+	modified := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: testutils.State(t, s, config).Raw},
+		Plan:   plan,
+		State:  read.State,
+	}, modified)
+	require.False(t, modified.Diagnostics.HasError(), modified.Diagnostics)
+	assert.True(t, modified.Plan.Raw.Equal(read.State.Raw), "%s != %s", modified.Plan.Raw, read.State.Raw)
+}
+
+func TestModifyPlanUnsupportedDatatype(t *testing.T) {
+	ctx := context.Background()
+	r := &checkJsonPropertyResource{}
+	s := testutils.Configure(t, r, testutils.NewClient(t))
+
+	// A server supporting the datatypes of the OpenAPI document only
+	r.capabilities = testutils.DetectCapabilities(t)
+
+	ratio, _, err := big.ParseFloat("0.93", 10, 512, big.ToNearestEven)
+	require.NoError(t, err)
+	model := CheckJsonPropertyModel{
+		ID:                 types.Int64Unknown(),
+		ApplicationID:      types.Int64Value(1),
+		DataSourceID:       types.Int64Value(1),
+		Name:               types.StringValue("Latency"),
+		Crontab:            crontab.NewValue("*/5 * * * *"),
+		Enabled:            types.BoolValue(true),
+		Path:               jsonpath.NewValue("$.latency_ratio"),
+		DataType:           types.StringValue("float"),
+		Operator:           types.StringValue("lt"),
+		Value:              types.DynamicValue(types.NumberValue(ratio)),
+		CrontabDescription: types.StringUnknown(),
+		NextRuns:           types.ListUnknown(types.StringType),
+	}
+	raw := testutils.State(t, s, model).Raw
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: raw}}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: raw},
+		State:  tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, `datatype "float" is not supported by this Folge server version (mock).`, resp.Diagnostics[0].Detail())
+}
+
+func TestCreateFloat(t *testing.T) {
+	ctx := context.Background()
+	// A server supporting float, which the OpenAPI document doesn't have yet
+	opts := []mock.Option{mock.WithEnumValues("datatype", "float")}
+	client := testutils.NewClient(t, opts...)
+	r := &checkJsonPropertyResource{}
+	s := testutils.Configure(t, r, client)
+	r.capabilities = testutils.DetectCapabilities(t, opts...)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+	ratio, _, err := big.ParseFloat("0.93", 10, 512, big.ToNearestEven)
+	require.NoError(t, err)
+	model := CheckJsonPropertyModel{
+		ID:                 types.Int64Unknown(),
+		ApplicationID:      types.Int64Value(int64(appId)),
+		DataSourceID:       types.Int64Value(int64(dsId)),
+		Name:               types.StringValue("Latency"),
+		Crontab:            crontab.NewValue("*/5 * * * *"),
+		Enabled:            types.BoolValue(true),
+		Path:               jsonpath.NewValue("$.latency_ratio"),
+		DataType:           types.StringValue("float"),
+		Operator:           types.StringValue("lt"),
+		Value:              types.DynamicValue(types.NumberValue(ratio)),
+		CrontabDescription: types.StringUnknown(),
+		NextRuns:           types.ListUnknown(types.StringType),
+	}
+
+	raw := testutils.State(t, s, model).Raw
+	plan := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: raw}}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: raw},
+		State:  tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}, plan)
+	require.False(t, plan.Diagnostics.HasError(), plan.Diagnostics)
+
+	resp := testutils.Create(t, r, s, model)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state CheckJsonPropertyModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	content, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, int(state.ID.ValueInt64()))
+	require.NoError(t, err)
+	check, err := content.JSON200.AsJsonDataCheckTyped()
+	require.NoError(t, err)
+	assert.Equal(t, "0.93", check.Value)
+}
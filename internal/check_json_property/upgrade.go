@@ -0,0 +1,88 @@
+package checkjsonproperty
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/timestamp"
+)
+
+// checkJsonPropertyModelV0 is the state before the value attribute was added.
+type checkJsonPropertyModelV0 struct {
+	ID            types.Int64  `tfsdk:"id"`
+	ApplicationID types.Int64  `tfsdk:"application_id"`
+	DataSourceID  types.Int64  `tfsdk:"datasource_id"`
+	Name          types.String `tfsdk:"name"`
+	Crontab       types.String `tfsdk:"crontab"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Path          types.String `tfsdk:"path"`
+	DataType      types.String `tfsdk:"datatype"`
+	Operator      types.String `tfsdk:"operator"`
+	ValueBoolean  types.Bool   `tfsdk:"value_bool"`
+	ValueString   types.String `tfsdk:"value_string"`
+	ValueInt      types.Int64  `tfsdk:"value_int"`
+	ValueDateTime types.String `tfsdk:"value_datetime"`
+}
+
+// UpgradeState moves the value of older states into the value attribute. The
+// deprecated value attribute is kept as well, as the configurations of these
+// states still use it, so they don't show a diff after the upgrade.
+func (r *checkJsonPropertyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":             schema.Int64Attribute{Computed: true},
+					"application_id": schema.Int64Attribute{Required: true},
+					"datasource_id":  schema.Int64Attribute{Required: true},
+					"name":           schema.StringAttribute{Required: true},
+					"enabled":        schema.BoolAttribute{Optional: true, Computed: true},
+					"crontab":        schema.StringAttribute{Required: true},
+					"path":           schema.StringAttribute{Required: true},
+					"datatype":       schema.StringAttribute{Required: true},
+					"operator":       schema.StringAttribute{Required: true},
+					"value_bool":     schema.BoolAttribute{Optional: true},
+					"value_int":      schema.Int64Attribute{Optional: true},
+					"value_string":   schema.StringAttribute{Optional: true},
+					"value_datetime": schema.StringAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: upgradeStateV0,
+		},
+	}
+}
+
+func upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior checkJsonPropertyModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := CheckJsonPropertyModel{
+		ID:            prior.ID,
+		ApplicationID: prior.ApplicationID,
+		DataSourceID:  prior.DataSourceID,
+		Name:          prior.Name,
+		Crontab:       crontab.Value{StringValue: prior.Crontab},
+		Timezone:      types.StringNull(),
+		Enabled:       prior.Enabled,
+		Path:          jsonpath.Value{StringValue: prior.Path},
+		DataType:      prior.DataType,
+		Operator:      prior.Operator,
+		ValueBoolean:  prior.ValueBoolean,
+		ValueString:   prior.ValueString,
+		ValueInt:      prior.ValueInt,
+		ValueDateTime: timestamp.Value{StringValue: prior.ValueDateTime},
+	}
+	state.Value = state.legacyValue()
+	state.CrontabDescription = crontab.DescriptionValue(state.Crontab)
+	state.NextRuns = crontab.NextRunsValue(state.Crontab, state.Timezone, time.Now())
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,113 @@
+package crontab
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ServerLocation is the timezone the server runs crontabs in. The Folge API
+// has no timezone setting.
+var ServerLocation = time.UTC
+
+// maxSamples bounds the number of runs inspected when calculating the
+// interval of a schedule.
+const maxSamples = 10000
+
+var parser = cron.NewParser(
+	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow,
+)
+
+// Parse parses a standard 5-field crontab expression or one of the macros
+// like @hourly and @daily. Intervals like `@every 5m` are not crontabs, so
+// they are rejected.
+func Parse(expr string) (cron.Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		macro, ok := macros[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported macro %s", expr)
+		}
+		expr = macro
+	}
+	if strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		return nil, fmt.Errorf("timezones are not supported in the expression")
+	}
+	return parser.Parse(expr)
+}
+
+// MinInterval returns the shortest time between two consecutive runs of the
+// schedule, sampled over a year. It returns false when fewer than two runs
+// were sampled, e.g. for yearly schedules, as the interval is unknown then.
+func MinInterval(s cron.Schedule) (time.Duration, bool) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	var result time.Duration
+	sampled := false
+	prev := s.Next(start)
+	for i := 0; i < maxSamples && !prev.IsZero() && prev.Before(end); i++ {
+		next := s.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(prev); !sampled || gap < result {
+			result = gap
+		}
+		sampled = true
+		prev = next
+	}
+	return result, sampled
+}
+
+// Equivalent reports whether both expressions describe the same schedule.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+	sa, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	sb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+
+	x, ok := sa.(*cron.SpecSchedule)
+	if !ok {
+		return false
+	}
+	y, ok := sb.(*cron.SpecSchedule)
+	return ok && *x == *y
+}
+
+// NextRuns returns the next n runs of the schedule after from. The schedule
+// runs in the timezone of the server, the timezone only sets the location of
+// the returned times. They are in UTC when the timezone is empty.
+func NextRuns(expr string, timezone string, from time.Time, n int) ([]time.Time, error) {
+	schedule, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	location := ServerLocation
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	runs := make([]time.Time, 0, n)
+	next := from.In(ServerLocation)
+	for len(runs) < n {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		runs = append(runs, next.In(location))
+	}
+	return runs, nil
+}
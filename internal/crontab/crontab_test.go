@@ -0,0 +1,140 @@
+package crontab
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEquivalent(t *testing.T) {
+	assert.True(t, Equivalent("@hourly", "0 * * * *"))
+	assert.True(t, Equivalent("*/1 * * * *", "* * * * *"))
+	assert.True(t, Equivalent("0 9 * * 1-5", "0 9 * * mon-fri"))
+	assert.False(t, Equivalent("@hourly", "@daily"))
+	assert.False(t, Equivalent("*/5 * * *", "*/5 * * *x"))
+}
+
+func TestParse(t *testing.T) {
+	for expr, valid := range map[string]bool{
+		"*/5 * * * *":      true,
+		"@hourly":          true,
+		"@DAILY":           true,
+		"@every 5m":        false,
+		"@fortnightly":     false,
+		"0 0 * * * *":      false,
+		"TZ=UTC 0 * * * *": false,
+	} {
+		_, err := Parse(expr)
+		assert.Equal(t, valid, err == nil, expr)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]string{
+		"*/5 * * * *":  "At every 5th minute (UTC)",
+		"0 9 * * 1-5":  "At 09:00 on Monday through Friday (UTC)",
+		"30 */2 * * *": "At minute 30 past every 2nd hour (UTC)",
+		"* 9 1 1,7 *":  "At every minute past hour 9 on day-of-month 1 in January, July (UTC)",
+		"@hourly":      "At minute 0 (UTC)",
+		"@daily":       "At 00:00 (UTC)",
+		"@every 1h30m": "",
+		"*/5 * * *":    "",
+	}
+	for expr, expected := range cases {
+		assert.Equal(t, expected, Describe(expr), expr)
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	from := time.Date(2024, 1, 1, 10, 2, 0, 0, time.UTC)
+
+	runs, err := NextRuns("*/5 * * * *", "", from, 3)
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC), runs[0].UTC())
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC), runs[2].UTC())
+
+	// The schedule runs in UTC on the server, the timezone only formats the
+	// runs, also when the start time is in another timezone
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+	runs, err = NextRuns("0 9 * * *", "Europe/Amsterdam", from.In(amsterdam), 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), runs[0].UTC())
+	assert.Equal(t, amsterdam, runs[0].Location())
+
+	runs, err = NextRuns("0 9 * * *", "", from.In(amsterdam), 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, runs[0].Location())
+
+	list := NextRunsValue(NewValue("0 9 * * *"), types.StringValue("Europe/Amsterdam"), from)
+	require.Len(t, list.Elements(), NextRunsCount)
+	assert.Equal(t, `"2024-01-02T10:00:00+01:00"`, list.Elements()[0].String())
+
+	list = NextRunsValue(NewValue("0 9 * * *"), types.StringNull(), from)
+	assert.Equal(t, `"2024-01-02T09:00:00Z"`, list.Elements()[0].String())
+}
+
+func TestValidateAttribute(t *testing.T) {
+	ctx := context.Background()
+	for expr, valid := range map[string]bool{
+		"*/5 * * * *":                     true,
+		"@daily":                          true,
+		"*/5 * * *":                       false,
+		"61 * * * *":                      false,
+		"CRON_TZ=Europe/Amsterdam @daily": false,
+		"@every 5m":                       false,
+	} {
+		resp := &xattr.ValidateAttributeResponse{}
+		NewValue(expr).ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("crontab")}, resp)
+		assert.Equal(t, !valid, resp.Diagnostics.HasError(), expr)
+	}
+}
+
+func TestSemanticEquals(t *testing.T) {
+	equal, diags := NewValue("@hourly").StringSemanticEquals(context.Background(), NewValue("0 * * * *"))
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+}
+
+func TestFromRemote(t *testing.T) {
+	stored := "0 * * * *"
+	assert.Equal(t, "@hourly", FromRemote(&stored, NewValue("@hourly")).ValueString())
+	assert.Equal(t, "0 * * * *", FromRemote(&stored, NewValue("@daily")).ValueString())
+	assert.True(t, FromRemote(nil, NewValue("@daily")).IsNull())
+}
+
+func TestToRemote(t *testing.T) {
+	assert.Equal(t, "0 9 * * 1-5", *ToRemote(NewValue("0 9 * * 1-5")))
+	assert.Nil(t, ToRemote(NewNull()))
+	assert.Nil(t, ToRemote(NewUnknown()))
+}
+
+func TestMinInterval(t *testing.T) {
+	tests := []struct {
+		expr     string
+		interval time.Duration
+		ok       bool
+	}{
+		{"*/5 * * * *", 5 * time.Minute, true},
+		{"0,1 9 * * *", time.Minute, true},
+		{"@daily", 24 * time.Hour, true},
+		{"@yearly", 0, false},
+		{"0 0 29 2 *", 4 * 8766 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			require.NoError(t, err)
+			interval, ok := MinInterval(schedule)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.interval, interval)
+		})
+	}
+}
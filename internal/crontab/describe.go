@@ -0,0 +1,116 @@
+package crontab
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// macros maps the supported macros to the expression they stand for.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var weekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+var months = []string{"", "January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// Describe returns a human-readable description of a crontab expression like
+// "At every 5th minute past hour 9 on Monday through Friday (UTC)", or an
+// empty string when the expression is invalid. The server runs crontabs in
+// UTC, so the description is in UTC as well.
+func Describe(expr string) string {
+	if _, err := Parse(expr); err != nil {
+		return ""
+	}
+	return describe(strings.TrimSpace(expr)) + " (" + ServerLocation.String() + ")"
+}
+
+func describe(expr string) string {
+	if macro, ok := macros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	var parts []string
+	if isNumber(minute) && isNumber(hour) {
+		m, _ := strconv.Atoi(minute)
+		h, _ := strconv.Atoi(hour)
+		parts = append(parts, fmt.Sprintf("At %02d:%02d", h, m))
+	} else {
+		if minute == "*" {
+			parts = append(parts, "At every minute")
+		} else {
+			parts = append(parts, "At "+describeField(minute, "minute"))
+		}
+		if hour != "*" {
+			parts = append(parts, "past "+describeField(hour, "hour"))
+		}
+	}
+	if dom != "*" && dom != "?" {
+		parts = append(parts, "on "+describeField(dom, "day-of-month"))
+	}
+	if dow != "*" && dow != "?" {
+		parts = append(parts, "on "+describeNames(dow, weekdays))
+	}
+	if month != "*" {
+		parts = append(parts, "in "+describeNames(month, months))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeField describes a numeric field like `5`, `*/5` or `1-5`.
+func describeField(field string, unit string) string {
+	if step, ok := strings.CutPrefix(field, "*/"); ok {
+		return fmt.Sprintf("every %s %s", ordinal(step), unit)
+	}
+	return unit + " " + field
+}
+
+// describeNames describes a day-of-week or month field, replacing numbers by
+// their names.
+func describeNames(field string, names []string) string {
+	var result []string
+	for _, item := range strings.Split(field, ",") {
+		bounds := strings.SplitN(item, "-", 2)
+		for i, bound := range bounds {
+			if n, err := strconv.Atoi(bound); err == nil && n >= 0 && n < len(names) && names[n] != "" {
+				bounds[i] = names[n]
+			}
+		}
+		result = append(result, strings.Join(bounds, " through "))
+	}
+	return strings.Join(result, ", ")
+}
+
+func ordinal(number string) string {
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return number
+	}
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return number + "th"
+	case n%10 == 1:
+		return number + "st"
+	case n%10 == 2:
+		return number + "nd"
+	case n%10 == 3:
+		return number + "rd"
+	}
+	return number + "th"
+}
+
+func isNumber(field string) bool {
+	_, err := strconv.Atoi(field)
+	return err == nil
+}
@@ -0,0 +1,132 @@
+package crontab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NextRunsCount is the number of upcoming runs exposed on checks.
+const NextRunsCount = 5
+
+// FromRemote returns the crontab of a schedule stored by the API. The prior
+// spelling of the crontab is kept when it is equivalent.
+func FromRemote(stored *string, prior Value) Value {
+	if stored == nil {
+		return NewNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() && Equivalent(prior.ValueString(), *stored) {
+		return prior
+	}
+	return NewValue(*stored)
+}
+
+// DescriptionValue returns the human-readable description of the schedule.
+func DescriptionValue(value Value) types.String {
+	if value.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if value.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(Describe(value.ValueString()))
+}
+
+// NextRunsValue returns the next runs of the schedule after from, formatted
+// as RFC 3339 timestamps in the timezone.
+func NextRunsValue(value Value, timezone types.String, from time.Time) types.List {
+	if value.IsUnknown() || timezone.IsUnknown() {
+		return types.ListUnknown(types.StringType)
+	}
+
+	runs, err := NextRuns(value.ValueString(), timezone.ValueString(), from, NextRunsCount)
+	if value.IsNull() || err != nil {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, len(runs))
+	for i, run := range runs {
+		elements[i] = types.StringValue(run.Format(time.RFC3339))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// PlanSchedule sets the computed schedule attributes of a planned check. The
+// next runs are only known ahead when the schedule is unchanged, as they
+// depend on the time of the apply.
+func PlanSchedule(ctx context.Context, state tfsdk.State, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var value Value
+	var timezone types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("crontab"), &value)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("timezone"), &timezone)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	nextRuns := types.ListUnknown(types.StringType)
+	if !state.Raw.IsNull() {
+		var priorValue Value
+		var priorTimezone types.String
+		var priorRuns types.List
+		diags.Append(state.GetAttribute(ctx, path.Root("crontab"), &priorValue)...)
+		diags.Append(state.GetAttribute(ctx, path.Root("timezone"), &priorTimezone)...)
+		diags.Append(state.GetAttribute(ctx, path.Root("next_runs"), &priorRuns)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		if !value.IsUnknown() && Equivalent(priorValue.ValueString(), value.ValueString()) && priorTimezone.Equal(timezone) {
+			nextRuns = priorRuns
+		}
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("crontab_description"), DescriptionValue(value))...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("next_runs"), nextRuns)...)
+	return diags
+}
+
+// TimezoneValidator returns a validator which checks that a string is a
+// timezone from the IANA database like `Europe/Amsterdam`.
+func TimezoneValidator() validator.String {
+	return timezoneValidator{}
+}
+
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be a timezone from the IANA database"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	timezone := req.ConfigValue.ValueString()
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timezone",
+			fmt.Sprintf("The timezone %q is not a timezone from the IANA database, like Europe/Amsterdam.", timezone))
+	}
+}
+
+// ToRemote returns the schedule in the format stored by the API. The API has
+// no timezone setting, so the timezone only applies to the next runs.
+func ToRemote(value Value) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
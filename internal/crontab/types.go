@@ -0,0 +1,108 @@
+package crontab
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/labd/terraform-provider-folge/internal/stringtype"
+)
+
+var (
+	_ basetypes.StringTypable                    = Type{}
+	_ basetypes.StringValuableWithSemanticEquals = Value{}
+	_ xattr.ValidateableAttribute                = Value{}
+)
+
+// Type is the attribute type of crontab expressions. Values are validated
+// when the configuration is validated, and equivalent expressions like
+// `@hourly` and `0 * * * *` are considered equal.
+type Type struct {
+	basetypes.StringType
+}
+
+func (t Type) String() string {
+	return "crontab.Type"
+}
+
+func (t Type) Equal(o attr.Type) bool {
+	other, ok := o.(Type)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t Type) ValueType(_ context.Context) attr.Value {
+	return Value{}
+}
+
+func (t Type) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Value{StringValue: in}, nil
+}
+
+func (t Type) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringtype.ValueFromTerraform(ctx, t, in)
+}
+
+// Value is a crontab expression.
+type Value struct {
+	basetypes.StringValue
+}
+
+// NewValue returns a known crontab expression.
+func NewValue(expr string) Value {
+	return Value{StringValue: basetypes.NewStringValue(expr)}
+}
+
+// NewNull returns a null crontab expression.
+func NewNull() Value {
+	return Value{StringValue: basetypes.NewStringNull()}
+}
+
+// NewUnknown returns an unknown crontab expression.
+func NewUnknown() Value {
+	return Value{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v Value) Type(_ context.Context) attr.Type {
+	return Type{}
+}
+
+func (v Value) Equal(o attr.Value) bool {
+	other, ok := o.(Value)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true when both expressions describe the same
+// schedule.
+func (v Value) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	return stringtype.SemanticEquals(v, newValuable, Equivalent)
+}
+
+// ValidateAttribute checks that the value is a valid crontab expression.
+func (v Value) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	expr := v.ValueString()
+	if strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid crontab",
+			fmt.Sprintf("The crontab %q contains a timezone, use the timezone attribute instead.", expr))
+		return
+	}
+	if _, err := Parse(expr); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid crontab",
+			fmt.Sprintf("The crontab %q is not a valid 5-field crontab expression or macro like @hourly: %s", expr, err))
+	}
+}
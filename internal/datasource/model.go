@@ -1,11 +1,15 @@
 package datasource
 
 import (
-	"errors"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
 type DataSourceModel struct {
@@ -13,13 +17,16 @@ type DataSourceModel struct {
 	ApplicationID types.Int64  `tfsdk:"application_id"`
 	Name          types.String `tfsdk:"name"`
 	URL           types.String `tfsdk:"url"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
 
 	BasicAuth *BasicAuthModel `tfsdk:"basic_auth"`
 }
 
 type BasicAuthModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
 }
 
 func (m *DataSourceModel) toCreateInput() folge.ApplicationsDataSourcesCreateJSONRequestBody {
@@ -34,13 +41,14 @@ func (m *DataSourceModel) toUpdateInput() folge.ApplicationsDataSourcesUpdateJSO
 
 func (m *DataSourceModel) createRequest() folge.DataSourceRequest {
 	data := folge.HttpDataSourceTypedRequest{
-		Label: m.Name.ValueStringPointer(),
-		Url:   m.URL.ValueString(),
+		Enabled: m.Enabled.ValueBoolPointer(),
+		Label:   m.Name.ValueStringPointer(),
+		Url:     m.URL.ValueString(),
 	}
 
 	if m.BasicAuth != nil {
 		data.BasicAuthUsername = m.BasicAuth.Username.ValueStringPointer()
-		data.BasicAuthPassword = m.BasicAuth.Password.ValueStringPointer()
+		data.BasicAuthPassword = m.sentPassword()
 	}
 
 	req := folge.DataSourceRequest{}
@@ -50,27 +58,79 @@ func (m *DataSourceModel) createRequest() folge.DataSourceRequest {
 	return req
 }
 
-func (m *DataSourceModel) fromRemote(i folge.DataSource, applicationId int) error {
-	t, err := i.ValueByDiscriminator()
+func (m *DataSourceModel) fromRemote(i folge.DataSource, applicationId int, sentHash *passwordHash) error {
+	if err := utils.DataSourceType(i, "http"); err != nil {
+		return err
+	}
+	d, err := i.AsHttpDataSourceTyped()
 	if err != nil {
 		return err
 	}
 
-	switch d := t.(type) {
-	case folge.HttpDataSourceTyped:
-		m.ID = types.Int64Value(int64(*d.Id))
-		m.Name = types.StringPointerValue(d.Label)
-		m.URL = types.StringValue(d.Url)
-		m.ApplicationID = types.Int64Value(int64(applicationId))
-
-		if (d.BasicAuthUsername != nil) || (d.BasicAuthPassword != nil) {
-			m.BasicAuth = &BasicAuthModel{
-				Username: types.StringPointerValue(d.BasicAuthUsername),
-				Password: types.StringPointerValue(d.BasicAuthPassword),
-			}
+	m.ID = types.Int64Value(int64(*d.Id))
+	m.Name = types.StringPointerValue(d.Label)
+	m.URL = types.StringValue(d.Url)
+	m.Enabled = utils.EnabledValue(d.Enabled)
+	m.ApplicationID = types.Int64Value(int64(applicationId))
+
+	m.BasicAuth = basicAuthFromRemote(d, m.BasicAuth, sentHash)
+	return nil
+}
+
+// sentPassword returns the basic auth password which is sent to the API, from
+// either the classic or the write-only attribute.
+func (m *DataSourceModel) sentPassword() *string {
+	if m.BasicAuth == nil {
+		return nil
+	}
+	if !m.BasicAuth.Password.IsNull() {
+		return m.BasicAuth.Password.ValueStringPointer()
+	}
+	return m.BasicAuth.PasswordWO.ValueStringPointer()
+}
+
+// readWriteOnly reads the write-only password from the configuration, as
+// Terraform never includes it in the plan.
+func (m *DataSourceModel) readWriteOnly(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	if m.BasicAuth == nil {
+		return nil
+	}
+	return config.GetAttribute(ctx, path.Root("basic_auth").AtName("password_wo"), &m.BasicAuth.PasswordWO)
+}
+
+// basicAuthFromRemote returns the basic auth credentials of the datasource, or
+// nil when they were removed. The password is only stored in the state when
+// the classic password attribute is used. It is kept from the previous state
+// unless the API returns a password which differs from the one last sent,
+// which is compared against its hash when known.
+func basicAuthFromRemote(d folge.HttpDataSourceTyped, prior *BasicAuthModel, sentHash *passwordHash) *BasicAuthModel {
+	if d.BasicAuthUsername == nil && d.BasicAuthPassword == nil {
+		return nil
+	}
+
+	auth := &BasicAuthModel{
+		Username:          types.StringPointerValue(d.BasicAuthUsername),
+		Password:          types.StringNull(),
+		PasswordWO:        types.StringNull(),
+		PasswordWOVersion: types.Int64Null(),
+	}
+	if prior == nil {
+		return auth
+	}
+
+	changed := d.BasicAuthPassword != nil &&
+		passwordChanged(*d.BasicAuthPassword, prior.Password.ValueString(), sentHash)
+
+	auth.PasswordWOVersion = prior.PasswordWOVersion
+	if !prior.Password.IsNull() {
+		auth.Password = prior.Password
+		if changed {
+			auth.Password = types.StringValue(*d.BasicAuthPassword)
 		}
-	default:
-		return errors.New("unknown data source type")
+	} else if changed && sentHash != nil {
+		// The write-only password can't be compared in the plan, so clear the
+		// version to have the next apply send the configured password again
+		auth.PasswordWOVersion = types.Int64Null()
 	}
-	return nil
+	return auth
 }
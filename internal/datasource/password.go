@@ -0,0 +1,125 @@
+package datasource
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// passwordHashKey is the private state key holding the hash of the last
+// basic auth password sent to the API.
+const passwordHashKey = "basic_auth_password"
+
+// privateState is implemented by the private state of a resource.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// passwordHash is an HMAC of a password keyed with a random salt. The state is
+// readable by more people than the password, so the salt prevents looking up
+// the hash of common passwords.
+type passwordHash struct {
+	Salt string `json:"salt"`
+	HMAC string `json:"hmac_sha256"`
+}
+
+func newPasswordHash(password string) *passwordHash {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+	return &passwordHash{
+		Salt: hex.EncodeToString(salt),
+		HMAC: hex.EncodeToString(passwordHMAC(salt, password)),
+	}
+}
+
+func passwordHMAC(salt []byte, password string) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+// matches reports whether the hash is of the password.
+func (h *passwordHash) matches(password string) bool {
+	salt, err := hex.DecodeString(h.Salt)
+	if err != nil {
+		return false
+	}
+	sum, err := hex.DecodeString(h.HMAC)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sum, passwordHMAC(salt, password))
+}
+
+// hashSentPassword returns the hash of the password sent to the API, or nil
+// when no password was sent.
+func hashSentPassword(password *string) *passwordHash {
+	if password == nil {
+		return nil
+	}
+	return newPasswordHash(*password)
+}
+
+// getPasswordHash returns the hash of the last password sent to the API, or
+// nil when it is unknown.
+func getPasswordHash(ctx context.Context, private privateState) (*passwordHash, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, passwordHashKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+
+	var value passwordHash
+	if err := json.Unmarshal(data, &value); err != nil {
+		diags.AddError("Invalid private state", "Could not decode the hash of the basic auth password: "+err.Error())
+		return nil, diags
+	}
+	if value.Salt == "" || value.HMAC == "" {
+		// Hashes without a salt are ignored, so they are replaced on the next
+		// apply
+		return nil, diags
+	}
+	return &value, diags
+}
+
+// setPasswordHash stores the hash of the password sent to the API, or removes
+// it when no password was sent.
+func setPasswordHash(ctx context.Context, private privateState, hash *passwordHash) diag.Diagnostics {
+	if hash == nil {
+		return private.SetKey(ctx, passwordHashKey, nil)
+	}
+
+	data, err := json.Marshal(hash)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", "Could not encode the hash of the basic auth password: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, passwordHashKey, data)
+}
+
+// passwordChanged reports whether the password returned by the API differs
+// from the password which was sent. Masked passwords are never considered
+// changed, as the actual value is unknown.
+func passwordChanged(remote string, sent string, sentHash *passwordHash) bool {
+	if isMasked(remote) {
+		return false
+	}
+	if sentHash != nil {
+		return !sentHash.matches(remote)
+	}
+	return remote != sent
+}
+
+// isMasked reports whether a password was masked by the API, like `********`.
+func isMasked(password string) bool {
+	return password != "" && strings.Trim(password, "*•") == ""
+}
@@ -5,13 +5,19 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/policy"
 	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
@@ -19,6 +25,7 @@ import (
 var (
 	_ resource.Resource                = &dataSourceResource{}
 	_ resource.ResourceWithConfigure   = &dataSourceResource{}
+	_ resource.ResourceWithModifyPlan  = &dataSourceResource{}
 	_ resource.ResourceWithImportState = &dataSourceResource{}
 )
 
@@ -29,7 +36,9 @@ func NewDataSourceResource() resource.Resource {
 
 // dataSourceResource is the resource implementation.
 type dataSourceResource struct {
-	client folge.ClientWithResponsesInterface
+	client       folge.ClientWithResponsesInterface
+	capabilities *capabilities.Capabilities
+	policy       *policy.Policy
 }
 
 // Metadata returns the data source type name.
@@ -40,7 +49,7 @@ func (r *dataSourceResource) Metadata(_ context.Context, req resource.MetadataRe
 // Schema defines the schema for the data source.
 func (r *dataSourceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "The datasource",
+		Description: "An http datasource. Datasources of other types can't be managed by this provider version yet.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "The ID of the datasource",
@@ -50,8 +59,11 @@ func (r *dataSourceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"application_id": schema.Int64Attribute{
-				Description: "The ID of the applicaton",
+				Description: "The ID of the applicaton. Changing it replaces the datasource and its checks.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name.",
@@ -61,6 +73,12 @@ func (r *dataSourceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "The URL.",
 				Required:    true,
 			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the datasource is enabled. Disabling a datasource pauses all of its checks. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"basic_auth": schema.SingleNestedBlock{
@@ -71,8 +89,25 @@ func (r *dataSourceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 						Required:    true,
 					},
 					"password": schema.StringAttribute{
-						Description: "The password.",
-						Required:    true,
+						Description: "The password. It is stored in the state, use `password_wo` instead on Terraform 1.11 and later.",
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
+					},
+					"password_wo": schema.StringAttribute{
+						Description: "The password, which is only sent to Folge and never stored in the state. Requires Terraform 1.11 or later.",
+						Optional:    true,
+						Sensitive:   true,
+						WriteOnly:   true,
+					},
+					"password_wo_version": schema.Int64Attribute{
+						Description: "The version of `password_wo`. Change it to send a new password to Folge, as changes to write-only attributes are not detected.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
 					},
 				},
 			},
@@ -81,12 +116,50 @@ func (r *dataSourceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 }
 
 // Configure adds the provider configured client to the data source.
-func (r *dataSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (r *dataSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = utils.GetClient(req.ProviderData)
+	data, diags := utils.GetProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = data.Client
+	r.capabilities = data.Capabilities
+	r.policy = data.Policy
+}
+
+// ModifyPlan verifies the planned datasource is supported by the server and
+// enforces the provider policy.
+func (r *dataSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DataSourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.capabilities.RequireDataSourceType("http")...)
+	if plan.BasicAuth != nil {
+		resp.Diagnostics.Append(r.capabilities.RequireField(
+			path.Root("basic_auth"), "HttpDataSourceTypedRequest", "basic_auth_username")...)
+	}
+	// Datasources are enabled by default, so only disabling one requires support
+	if !plan.Enabled.IsUnknown() && !plan.Enabled.ValueBool() {
+		resp.Diagnostics.Append(r.capabilities.RequireField(
+			path.Root("enabled"), "HttpDataSourceTypedRequest", "enabled")...)
+	}
+
+	resp.Diagnostics.Append(r.policy.ValidateLabel(path.Root("name"), plan.Name)...)
+	resp.Diagnostics.Append(r.policy.ValidateURL(path.Root("url"), plan.URL)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -95,6 +168,7 @@ func (r *dataSourceResource) Create(ctx context.Context, req resource.CreateRequ
 	var plan DataSourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(plan.readWriteOnly(ctx, req.Config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -124,7 +198,8 @@ func (r *dataSourceResource) Create(ctx context.Context, req resource.CreateRequ
 	datasource := content.JSON201
 
 	// Map response body to schema and populate Computed attribute values
-	if err := plan.fromRemote(*datasource, appId); err != nil {
+	sent := hashSentPassword(plan.sentPassword())
+	if err := plan.fromRemote(*datasource, appId, sent); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating datasource",
 			"Could not create datasource, unexpected error: "+err.Error(),
@@ -132,6 +207,12 @@ func (r *dataSourceResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	// Remember the password to detect changes made outside of Terraform. The
+	// private state is only available when running in Terraform.
+	if resp.Private != nil {
+		resp.Diagnostics.Append(setPasswordHash(ctx, resp.Private, sent)...)
+	}
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -153,6 +234,12 @@ func (r *dataSourceResource) Read(ctx context.Context, req resource.ReadRequest,
 	id := int(state.ID.ValueInt64())
 	appId := utils.AsInt(state.ApplicationID)
 	content, err := r.client.ApplicationsDataSourcesRetrieveWithResponse(ctx, appId, id)
+	if utils.IsNotFound(content, err) {
+		reason := utils.MissingParentReason(ctx, r.client, appId, 0)
+		resp.Diagnostics.Append(utils.NotFoundWarning("datasource", id, reason))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if d := utils.CheckGetError("datasource", id, content, err); d != nil {
 		resp.Diagnostics.Append(d)
 		return
@@ -160,8 +247,14 @@ func (r *dataSourceResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	datasource := *content.JSON200
 
+	sentHash, diags := getPasswordHash(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Overwrite items with refreshed state
-	if err := state.fromRemote(datasource, appId); err != nil {
+	if err := state.fromRemote(datasource, appId, sentHash); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Application",
 			fmt.Sprintf("Could not read Application ID %d: %s", state.ID.ValueInt64(), err.Error()),
@@ -183,6 +276,7 @@ func (r *dataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 	var plan DataSourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(plan.readWriteOnly(ctx, req.Config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -213,7 +307,8 @@ func (r *dataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 	datasource := *content.JSON200
 
 	// Map response body to schema and populate Computed attribute values
-	if err := plan.fromRemote(datasource, appId); err != nil {
+	sent := hashSentPassword(plan.sentPassword())
+	if err := plan.fromRemote(datasource, appId, sent); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating datasource",
 			"Could not update datasource, unexpected error: "+err.Error(),
@@ -221,6 +316,12 @@ func (r *dataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// Remember the password to detect changes made outside of Terraform. The
+	// private state is only available when running in Terraform.
+	if resp.Private != nil {
+		resp.Diagnostics.Append(setPasswordHash(ctx, resp.Private, sent)...)
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -239,7 +340,8 @@ func (r *dataSourceResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	id := utils.AsInt(state.ID)
-	content, err := r.client.ApplicationsDestroyWithResponse(ctx, id)
+	appId := utils.AsInt(state.ApplicationID)
+	content, err := r.client.ApplicationsDataSourcesDestroyWithResponse(ctx, appId, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting datasource",
@@ -247,7 +349,8 @@ func (r *dataSourceResource) Delete(ctx context.Context, req resource.DeleteRequ
 		)
 		return
 	}
-	if content.StatusCode() != http.StatusNoContent {
+	// A 404 means the datasource is already gone, which is fine
+	if !utils.IsDeleted(content) {
 		resp.Diagnostics.AddError(
 			"Error deleting datasource",
 			fmt.Sprintf(
@@ -257,9 +360,30 @@ func (r *dataSourceResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	d := utils.ConfirmDeleted(ctx, "datasource", id, func() (utils.ApiResponse, error) {
+		return r.client.ApplicationsDataSourcesRetrieveWithResponse(ctx, appId, id)
+	})
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
 }
 
 func (r *dataSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parents, selector, err := utils.SplitImportID(req.ID, "application_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	appId := parents[0]
+
+	// The datasource is either imported by ID or label:<label>
+	id, err := utils.ResolveDataSourceID(ctx, r.client, appId, selector)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing datasource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), int64(appId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
 }
@@ -0,0 +1,286 @@
+package datasource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/testutils"
+)
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &dataSourceResource{}
+	s := testutils.Configure(t, r, client)
+
+	otherId := testutils.CreateApplication(t, client, "Checkout")
+	appId := testutils.CreateApplication(t, client, "Search")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+	require.Equal(t, otherId, dsId)
+
+	model := DataSourceModel{
+		ID:            types.Int64Value(int64(dsId)),
+		ApplicationID: types.Int64Value(int64(appId)),
+		Name:          types.StringNull(),
+		URL:           types.StringValue("https://example.com/health"),
+	}
+
+	resp := testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	ds, err := client.ApplicationsDataSourcesRetrieveWithResponse(ctx, appId, dsId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, ds.StatusCode())
+
+	// The application sharing the ID of the datasource is untouched
+	app, err := client.ApplicationsRetrieveWithResponse(ctx, otherId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, app.StatusCode())
+
+	// Deleting a datasource which is already gone succeeds
+	resp = testutils.Delete(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func TestReadDrift(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &dataSourceResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	model := DataSourceModel{
+		ApplicationID: types.Int64Value(int64(appId)),
+		Name:          types.StringValue("Health"),
+		URL:           types.StringValue("https://example.com/health"),
+		Enabled:       types.BoolValue(true),
+		BasicAuth: &BasicAuthModel{
+			Username: types.StringValue("user"),
+			Password: types.StringValue("secret"),
+		},
+	}
+	id := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Label:             model.Name.ValueStringPointer(),
+		Url:               model.URL.ValueString(),
+		BasicAuthUsername: model.BasicAuth.Username.ValueStringPointer(),
+		BasicAuthPassword: model.BasicAuth.Password.ValueStringPointer(),
+	})
+	model.ID = types.Int64Value(int64(id))
+
+	// Rename the datasource and remove the credentials outside of Terraform
+	changed := DataSourceModel{
+		Name:    types.StringValue("Status"),
+		URL:     types.StringValue("https://example.com/status"),
+		Enabled: types.BoolValue(false),
+	}
+	_, err := client.ApplicationsDataSourcesUpdateWithResponse(ctx, appId, id, changed.toUpdateInput())
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state DataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "Status", state.Name.ValueString())
+	assert.Equal(t, "https://example.com/status", state.URL.ValueString())
+	assert.False(t, state.Enabled.ValueBool())
+	assert.Nil(t, state.BasicAuth)
+
+	// The unchanged configuration shows the drift in the plan
+	assert.Equal(t, []string{"basic_auth", "enabled", "name", "url"}, testutils.ConfigDiff(t, s, resp.State, model))
+}
+
+func TestBasicAuthFromRemote(t *testing.T) {
+	username := "user"
+	prior := &BasicAuthModel{
+		Username: types.StringValue("user"),
+		Password: types.StringValue("secret"),
+	}
+
+	// The password is kept when the API doesn't return it
+	auth := basicAuthFromRemote(folge.HttpDataSourceTyped{BasicAuthUsername: &username}, prior, nil)
+	require.NotNil(t, auth)
+	assert.Equal(t, "secret", auth.Password.ValueString())
+
+	assert.Nil(t, basicAuthFromRemote(folge.HttpDataSourceTyped{}, prior, nil))
+
+	// The password returned by the API is not stored when it is write-only
+	password := "secret"
+	auth = basicAuthFromRemote(folge.HttpDataSourceTyped{
+		BasicAuthUsername: &username,
+		BasicAuthPassword: &password,
+	}, &BasicAuthModel{
+		Username:          types.StringValue("user"),
+		PasswordWOVersion: types.Int64Value(2),
+	}, newPasswordHash("secret"))
+	require.NotNil(t, auth)
+	assert.True(t, auth.Password.IsNull())
+	assert.True(t, auth.PasswordWO.IsNull())
+	assert.Equal(t, int64(2), auth.PasswordWOVersion.ValueInt64())
+}
+
+func TestBasicAuthPasswordChanged(t *testing.T) {
+	username := "user"
+	remote := func(password string) folge.HttpDataSourceTyped {
+		return folge.HttpDataSourceTyped{BasicAuthUsername: &username, BasicAuthPassword: &password}
+	}
+	classic := &BasicAuthModel{
+		Username: types.StringValue("user"),
+		Password: types.StringValue("secret"),
+	}
+	writeOnly := &BasicAuthModel{
+		Username:          types.StringValue("user"),
+		PasswordWOVersion: types.Int64Value(1),
+	}
+	hash := newPasswordHash("secret")
+
+	// Masked passwords keep the configured value
+	auth := basicAuthFromRemote(remote("********"), classic, hash)
+	assert.Equal(t, "secret", auth.Password.ValueString())
+	auth = basicAuthFromRemote(remote("********"), classic, nil)
+	assert.Equal(t, "secret", auth.Password.ValueString())
+
+	// Passwords changed outside of Terraform show up as a diff
+	auth = basicAuthFromRemote(remote("other"), classic, hash)
+	assert.Equal(t, "other", auth.Password.ValueString())
+
+	auth = basicAuthFromRemote(remote("secret"), writeOnly, hash)
+	assert.Equal(t, int64(1), auth.PasswordWOVersion.ValueInt64())
+	auth = basicAuthFromRemote(remote("other"), writeOnly, hash)
+	assert.True(t, auth.PasswordWOVersion.IsNull())
+	assert.True(t, auth.Password.IsNull())
+}
+
+// fakePrivateState stores the private state of a resource in memory.
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+	} else {
+		p[key] = value
+	}
+	return nil
+}
+
+func TestPasswordHash(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+
+	hash, diags := getPasswordHash(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Nil(t, hash)
+
+	password := "secret"
+	require.False(t, setPasswordHash(ctx, private, hashSentPassword(&password)).HasError())
+	assert.NotContains(t, string(private[passwordHashKey]), password)
+
+	hash, diags = getPasswordHash(ctx, private)
+	require.False(t, diags.HasError())
+	require.NotNil(t, hash)
+	assert.True(t, hash.matches(password))
+	assert.False(t, hash.matches("other"))
+
+	// Each hash has its own salt, so equal passwords have different hashes
+	other := newPasswordHash(password)
+	assert.NotEqual(t, hash.Salt, other.Salt)
+	assert.NotEqual(t, hash.HMAC, other.HMAC)
+	assert.True(t, other.matches(password))
+
+	// Unsalted hashes of older versions are ignored
+	private[passwordHashKey] = []byte(`{"sha256":"2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"}`)
+	hash, diags = getPasswordHash(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Nil(t, hash)
+
+	require.False(t, setPasswordHash(ctx, private, nil).HasError())
+	assert.Empty(t, private)
+}
+
+func TestCreateWriteOnlyPassword(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &dataSourceResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	model := DataSourceModel{
+		ApplicationID: types.Int64Value(int64(appId)),
+		Name:          types.StringValue("Health"),
+		URL:           types.StringValue("https://example.com/health"),
+		Enabled:       types.BoolValue(true),
+		BasicAuth: &BasicAuthModel{
+			Username:          types.StringValue("user"),
+			PasswordWO:        types.StringValue("secret"),
+			PasswordWOVersion: types.Int64Value(1),
+		},
+	}
+	config := testutils.State(t, s, model)
+
+	// Terraform never includes write-only values in the plan
+	model.ID = types.Int64Unknown()
+	model.BasicAuth.PasswordWO = types.StringNull()
+	plan := testutils.State(t, s, model)
+
+	resp := &resource.CreateResponse{State: testutils.State(t, s, DataSourceModel{})}
+	r.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: config.Raw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: plan.Raw},
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state DataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.NotNil(t, state.BasicAuth)
+	assert.True(t, state.BasicAuth.Password.IsNull())
+	assert.True(t, state.BasicAuth.PasswordWO.IsNull())
+	assert.Equal(t, int64(1), state.BasicAuth.PasswordWOVersion.ValueInt64())
+
+	content, err := client.ApplicationsDataSourcesRetrieveWithResponse(ctx, appId, int(state.ID.ValueInt64()))
+	require.NoError(t, err)
+	ds, err := content.JSON200.AsHttpDataSourceTyped()
+	require.NoError(t, err)
+	require.NotNil(t, ds.BasicAuthPassword)
+	assert.Equal(t, "secret", *ds.BasicAuthPassword)
+}
+
+func TestReadRemoved(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	r := &dataSourceResource{}
+	s := testutils.Configure(t, r, client)
+
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	id := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+	model := DataSourceModel{
+		ID:            types.Int64Value(int64(id)),
+		ApplicationID: types.Int64Value(int64(appId)),
+		URL:           types.StringValue("https://example.com/health"),
+		Enabled:       types.BoolValue(true),
+	}
+
+	_, err := client.ApplicationsDataSourcesDestroyWithResponse(ctx, appId, id)
+	require.NoError(t, err)
+
+	resp := testutils.Read(t, r, testutils.State(t, s, model))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+}
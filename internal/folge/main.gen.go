@@ -146,38 +146,44 @@ type HttpDataSourceTypedRequest struct {
 
 // HttpStatusCheck defines model for HttpStatusCheck.
 type HttpStatusCheck struct {
-	Enabled    *bool  `json:"enabled,omitempty"`
-	Id         *int   `json:"id,omitempty"`
-	Label      string `json:"label"`
-	StatusCode int    `json:"status_code"`
+	Crontab    *string `json:"crontab,omitempty"`
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Id         *int    `json:"id,omitempty"`
+	Label      string  `json:"label"`
+	StatusCode int     `json:"status_code"`
 }
 
 // HttpStatusCheckRequest defines model for HttpStatusCheckRequest.
 type HttpStatusCheckRequest struct {
-	Enabled    *bool  `json:"enabled,omitempty"`
-	Label      string `json:"label"`
-	StatusCode int    `json:"status_code"`
+	Crontab    *string `json:"crontab,omitempty"`
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Label      string  `json:"label"`
+	StatusCode int     `json:"status_code"`
 }
 
 // HttpStatusCheckTyped defines model for HttpStatusCheckTyped.
 type HttpStatusCheckTyped struct {
-	Enabled    *bool  `json:"enabled,omitempty"`
-	Id         *int   `json:"id,omitempty"`
-	Label      string `json:"label"`
-	StatusCode int    `json:"status_code"`
-	Type       string `json:"type"`
+	Crontab    *string `json:"crontab,omitempty"`
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Id         *int    `json:"id,omitempty"`
+	Label      string  `json:"label"`
+	StatusCode int     `json:"status_code"`
+	Type       string  `json:"type"`
 }
 
 // HttpStatusCheckTypedRequest defines model for HttpStatusCheckTypedRequest.
 type HttpStatusCheckTypedRequest struct {
-	Enabled    *bool  `json:"enabled,omitempty"`
-	Label      string `json:"label"`
-	StatusCode int    `json:"status_code"`
-	Type       string `json:"type"`
+	Crontab    *string `json:"crontab,omitempty"`
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Label      string  `json:"label"`
+	StatusCode int     `json:"status_code"`
+	Type       string  `json:"type"`
 }
 
 // JsonDataCheck defines model for JsonDataCheck.
 type JsonDataCheck struct {
+	Crontab *string `json:"crontab,omitempty"`
+
 	// Datatype * `bool` - Boolean
 	// * `int` - Integer
 	// * `str` - String
@@ -198,6 +204,8 @@ type JsonDataCheck struct {
 
 // JsonDataCheckRequest defines model for JsonDataCheckRequest.
 type JsonDataCheckRequest struct {
+	Crontab *string `json:"crontab,omitempty"`
+
 	// Datatype * `bool` - Boolean
 	// * `int` - Integer
 	// * `str` - String
@@ -217,6 +225,8 @@ type JsonDataCheckRequest struct {
 
 // JsonDataCheckTyped defines model for JsonDataCheckTyped.
 type JsonDataCheckTyped struct {
+	Crontab *string `json:"crontab,omitempty"`
+
 	// Datatype * `bool` - Boolean
 	// * `int` - Integer
 	// * `str` - String
@@ -238,6 +248,8 @@ type JsonDataCheckTyped struct {
 
 // JsonDataCheckTypedRequest defines model for JsonDataCheckTypedRequest.
 type JsonDataCheckTypedRequest struct {
+	Crontab *string `json:"crontab,omitempty"`
+
 	// Datatype * `bool` - Boolean
 	// * `int` - Integer
 	// * `str` - String
@@ -0,0 +1,85 @@
+package jsoncheck
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+func TestValidateOperator(t *testing.T) {
+	attr := path.Root("operator")
+	assert.False(t, ValidateOperator(attr, folge.Eq, folge.Bool).HasError())
+	assert.False(t, ValidateOperator(attr, folge.Gt, folge.Int).HasError())
+	assert.False(t, ValidateOperator(attr, folge.Lt, folge.Datetime).HasError())
+
+	diags := ValidateOperator(attr, folge.Gt, folge.Bool)
+	require.True(t, diags.HasError())
+	assert.Equal(t, `The operator "gt" can't be used with the datatype "bool", only with "int", "float", "datetime".`, diags[0].Detail())
+
+	assert.False(t, ValidateOperator(attr, folge.Gt, Float).HasError())
+	assert.False(t, ValidateOperator(attr, Exists, Null).HasError())
+	assert.True(t, ValidateOperator(attr, Exists, folge.Int).HasError())
+
+	assert.Equal(t, []string{"eq", "exists", "gt", "lt", "neq", "not_exists"}, Operators())
+	assert.Equal(t, []string{"bool", "datetime", "float", "int", "null", "str"}, Datatypes())
+}
+
+func TestValue(t *testing.T) {
+	number := types.DynamicValue(types.NumberValue(big.NewFloat(42)))
+	value, err := ValueToRemote(folge.Int, number)
+	require.NoError(t, err)
+	assert.Equal(t, "42", value)
+
+	_, err = ValueToRemote(folge.Int, types.DynamicValue(types.NumberValue(big.NewFloat(1.5))))
+	assert.EqualError(t, err, "got 1.5")
+	_, err = ValueToRemote(folge.Datetime, types.DynamicValue(types.StringValue("2024-01-01")))
+	assert.Error(t, err)
+	_, err = ValueToRemote(folge.Bool, types.DynamicValue(types.StringValue("true")))
+	assert.EqualError(t, err, "got a string")
+
+	// Floats keep all digits in both directions
+	ratio, _, err := big.ParseFloat("0.93", 10, 512, big.ToNearestEven)
+	require.NoError(t, err)
+	value, err = ValueToRemote(Float, types.DynamicValue(types.NumberValue(ratio)))
+	require.NoError(t, err)
+	assert.Equal(t, "0.93", value)
+	result, err := ValueFromRemote(Float, "0.93", types.DynamicNull())
+	require.NoError(t, err)
+	assert.True(t, types.DynamicValue(types.NumberValue(ratio)).Equal(result))
+	value, err = ValueToRemote(Float, types.DynamicValue(types.NumberValue(big.NewFloat(3))))
+	require.NoError(t, err)
+	assert.Equal(t, "3", value)
+
+	// The prior float is kept when the server adds trailing zeros
+	prior := types.DynamicValue(types.NumberValue(big.NewFloat(3)))
+	result, err = ValueFromRemote(Float, "3.0", prior)
+	require.NoError(t, err)
+	assert.True(t, prior.Equal(result))
+
+	value, err = ValueToRemote(Null, types.DynamicNull())
+	require.NoError(t, err)
+	assert.Equal(t, "null", value)
+	result, err = ValueFromRemote(Null, "null", types.DynamicNull())
+	require.NoError(t, err)
+	assert.True(t, result.IsNull())
+
+	result, err = ValueFromRemote(folge.Int, "42", types.DynamicNull())
+	require.NoError(t, err)
+	assert.True(t, number.Equal(result))
+
+	// The prior timestamp is kept when the server reformats it
+	prior = types.DynamicValue(types.StringValue("2024-01-01T13:00:00+01:00"))
+	result, err = ValueFromRemote(folge.Datetime, `"2024-01-01T12:00:00Z"`, prior)
+	require.NoError(t, err)
+	assert.True(t, prior.Equal(result))
+
+	result, err = ValueFromRemote(folge.Datetime, `"2024-01-02T12:00:00Z"`, prior)
+	require.NoError(t, err)
+	assert.Equal(t, types.DynamicValue(types.StringValue("2024-01-02T12:00:00Z")), result)
+}
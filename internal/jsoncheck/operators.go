@@ -0,0 +1,85 @@
+// Package jsoncheck holds the operators and values of checks comparing a
+// property of a JSON document, shared by the resources managing them.
+package jsoncheck
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+// Datatypes and operators which are not in the OpenAPI document the client is
+// generated from yet. Servers without them are detected through their
+// capabilities at plan time.
+const (
+	Float     folge.DatatypeEnum = "float"
+	Null      folge.DatatypeEnum = "null"
+	Exists    folge.OperatorEnum = "exists"
+	NotExists folge.OperatorEnum = "not_exists"
+)
+
+// operatorDatatypes lists the datatypes each operator can be used with. New
+// operators of the API only need to be added here.
+var operatorDatatypes = map[folge.OperatorEnum][]folge.DatatypeEnum{
+	folge.Eq:  {folge.Bool, folge.Int, Float, folge.Str, folge.Datetime, Null},
+	folge.Neq: {folge.Bool, folge.Int, Float, folge.Str, folge.Datetime, Null},
+	folge.Gt:  {folge.Int, Float, folge.Datetime},
+	folge.Lt:  {folge.Int, Float, folge.Datetime},
+	Exists:    {Null},
+	NotExists: {Null},
+}
+
+// Operators returns all supported operators, sorted by name.
+func Operators() []string {
+	var result []string
+	for operator := range operatorDatatypes {
+		result = append(result, string(operator))
+	}
+	slices.Sort(result)
+	return result
+}
+
+// Datatypes returns all supported datatypes, sorted by name.
+func Datatypes() []string {
+	var result []string
+	for _, supported := range operatorDatatypes {
+		for _, datatype := range supported {
+			if !slices.Contains(result, string(datatype)) {
+				result = append(result, string(datatype))
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// KnownDatatype reports whether the datatype is supported by the provider.
+func KnownDatatype(datatype folge.DatatypeEnum) bool {
+	return slices.Contains(Datatypes(), string(datatype))
+}
+
+// ValidateOperator reports an error on the attribute when the operator can't
+// be used with the datatype. Unsupported operators and datatypes are reported
+// by the schema validators.
+func ValidateOperator(attr path.Path, operator folge.OperatorEnum, datatype folge.DatatypeEnum) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	supported, ok := operatorDatatypes[operator]
+	if !ok || !KnownDatatype(datatype) || slices.Contains(supported, datatype) {
+		return diags
+	}
+
+	names := make([]string, len(supported))
+	for i, d := range supported {
+		names[i] = fmt.Sprintf("%q", d)
+	}
+	diags.AddAttributeError(attr, "Invalid operator",
+		fmt.Sprintf("The operator %q can't be used with the datatype %q, only with %s.",
+			operator, datatype, strings.Join(names, ", ")))
+	return diags
+}
@@ -0,0 +1,173 @@
+package jsoncheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/timestamp"
+)
+
+// valueTypes describes the Terraform type of the value for each datatype.
+var valueTypes = map[folge.DatatypeEnum]string{
+	folge.Bool:     "a bool",
+	folge.Int:      "a whole number",
+	Float:          "a number",
+	folge.Str:      "a string",
+	folge.Datetime: "an RFC 3339 timestamp string",
+}
+
+// NeedsValue reports whether checks of the datatype compare against a value.
+// Checks of the null datatype only look at the presence of the property.
+func NeedsValue(datatype folge.DatatypeEnum) bool {
+	return datatype != Null
+}
+
+// ValidateValue reports an error on the attribute when the Terraform type of
+// the value doesn't match the datatype. Unknown values and datatypes are
+// skipped.
+func ValidateValue(attr path.Path, datatype folge.DatatypeEnum, value types.Dynamic) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !NeedsValue(datatype) {
+		if !value.IsNull() {
+			diags.AddAttributeError(attr, "Unexpected value",
+				fmt.Sprintf("value can't be set when the datatype is %q.", datatype))
+		}
+		return diags
+	}
+
+	expected, ok := valueTypes[datatype]
+	if !ok || value.IsNull() || value.IsUnknown() || value.IsUnderlyingValueUnknown() {
+		return diags
+	}
+	if value.IsUnderlyingValueNull() {
+		diags.AddAttributeError(attr, "Missing value",
+			fmt.Sprintf("The value can't be null, it must be %s for the datatype %q.", expected, datatype))
+		return diags
+	}
+
+	if _, err := ValueToRemote(datatype, value); err != nil {
+		diags.AddAttributeError(attr, "Invalid value",
+			fmt.Sprintf("The value must be %s for the datatype %q: %s.", expected, datatype, err))
+	}
+	return diags
+}
+
+// ValueToRemote returns the value as the JSON document sent to the API.
+// Floats are sent with all their digits, so they are stored as configured.
+func ValueToRemote(datatype folge.DatatypeEnum, value types.Dynamic) (string, error) {
+	if !NeedsValue(datatype) {
+		return "null", nil
+	}
+
+	var data any
+	switch v := value.UnderlyingValue().(type) {
+	case basetypes.BoolValue:
+		if datatype != folge.Bool {
+			return "", fmt.Errorf("got a bool")
+		}
+		data = v.ValueBool()
+
+	case basetypes.NumberValue:
+		if datatype == Float {
+			data = json.Number(v.ValueBigFloat().Text('g', -1))
+			break
+		}
+		if datatype != folge.Int {
+			return "", fmt.Errorf("got a number")
+		}
+		i, accuracy := v.ValueBigFloat().Int64()
+		if !v.ValueBigFloat().IsInt() || accuracy != big.Exact {
+			return "", fmt.Errorf("got %s", v.ValueBigFloat().Text('g', -1))
+		}
+		data = i
+
+	case basetypes.StringValue:
+		switch datatype {
+		case folge.Str:
+		case folge.Datetime:
+			if _, err := timestamp.Parse(v.ValueString()); err != nil {
+				return "", err
+			}
+		default:
+			return "", fmt.Errorf("got a string")
+		}
+		data = v.ValueString()
+
+	default:
+		return "", fmt.Errorf("got %s", value.UnderlyingValue().Type(context.Background()))
+	}
+
+	result, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// ValueFromRemote returns the value of the JSON document stored by the API.
+// The prior value is kept when it describes the same timestamp.
+func ValueFromRemote(datatype folge.DatatypeEnum, data string, prior types.Dynamic) (types.Dynamic, error) {
+	switch datatype {
+	case folge.Bool:
+		var dst bool
+		if err := json.Unmarshal([]byte(data), &dst); err != nil {
+			return types.DynamicNull(), fmt.Errorf("Invalid bool value: %s", err)
+		}
+		return types.DynamicValue(types.BoolValue(dst)), nil
+
+	case folge.Int:
+		var dst int64
+		if err := json.Unmarshal([]byte(data), &dst); err != nil {
+			return types.DynamicNull(), fmt.Errorf("Invalid int value: %s", err)
+		}
+		return types.DynamicValue(types.NumberValue(new(big.Float).SetInt64(dst))), nil
+
+	case Float:
+		var dst json.Number
+		if err := json.Unmarshal([]byte(data), &dst); err != nil {
+			return types.DynamicNull(), fmt.Errorf("Invalid float value: %s", err)
+		}
+		// Parsed with the precision Terraform uses, so configured values
+		// round-trip exactly
+		f, _, err := big.ParseFloat(dst.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return types.DynamicNull(), fmt.Errorf("Invalid float value: %s", err)
+		}
+		if n, ok := prior.UnderlyingValue().(basetypes.NumberValue); ok && !n.IsNull() && !n.IsUnknown() && n.ValueBigFloat().Cmp(f) == 0 {
+			return prior, nil
+		}
+		return types.DynamicValue(types.NumberValue(f)), nil
+
+	case folge.Str:
+		var dst string
+		if err := json.Unmarshal([]byte(data), &dst); err != nil {
+			return types.DynamicNull(), fmt.Errorf("Invalid string value: %s", err)
+		}
+		return types.DynamicValue(types.StringValue(dst)), nil
+
+	case folge.Datetime:
+		var dst string
+		if err := json.Unmarshal([]byte(data), &dst); err != nil {
+			return types.DynamicNull(), fmt.Errorf("Invalid datetime value: %s", err)
+		}
+		if s, ok := prior.UnderlyingValue().(basetypes.StringValue); ok && timestamp.Equivalent(s.ValueString(), dst) {
+			return prior, nil
+		}
+		return types.DynamicValue(types.StringValue(dst)), nil
+
+	case Null:
+		return types.DynamicNull(), nil
+
+	default:
+		return types.DynamicNull(), fmt.Errorf("unknown datatype: %s", datatype)
+	}
+}
@@ -0,0 +1,212 @@
+// Package jsonpath parses the JSON paths used by checks on JSON properties,
+// like `$.status`, `items[0].name` or `$['key.with.dots']`.
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Segment is a single key or array index of a path.
+type Segment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// SyntaxError describes an invalid path. The position is the 1-based offset
+// of the offending character.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// simpleKey matches keys which can be written in dot notation.
+var simpleKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Parse parses a path made of dotted keys, array indexes and quoted keys, with
+// an optional leading `$`.
+func Parse(path string) ([]Segment, error) {
+	p := &parser{input: []rune(path)}
+	return p.parse()
+}
+
+// Normalize returns the canonical form of the path, e.g. `$.a.b[0]` for
+// `a['b'][0]`.
+func Normalize(path string) (string, error) {
+	segments, err := Parse(path)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("$")
+	for _, segment := range segments {
+		switch {
+		case segment.IsIndex:
+			fmt.Fprintf(&b, "[%d]", segment.Index)
+		case simpleKey.MatchString(segment.Key):
+			b.WriteString("." + segment.Key)
+		default:
+			key := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(segment.Key)
+			b.WriteString("['" + key + "']")
+		}
+	}
+	return b.String(), nil
+}
+
+// Equivalent reports whether both paths select the same property.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+	sa, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	sb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return slices.Equal(sa, sb)
+}
+
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) parse() ([]Segment, error) {
+	if len(p.input) == 0 {
+		return nil, p.errorf("expected a key")
+	}
+
+	expectKey := true
+	if p.peek() == '$' {
+		p.pos++
+		if p.done() {
+			return nil, p.errorf(`expected "." or "[" after "$"`)
+		}
+		expectKey = false
+	}
+
+	var segments []Segment
+	if expectKey && p.peek() != '[' {
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, Segment{Key: key})
+	}
+
+	for !p.done() {
+		switch p.peek() {
+		case '.':
+			p.pos++
+			key, err := p.parseKey()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, Segment{Key: key})
+		case '[':
+			segment, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+		default:
+			return nil, p.errorf(`unexpected character %q, expected "." or "["`, p.peek())
+		}
+	}
+	return segments, nil
+}
+
+// parseKey parses a key in dot notation.
+func (p *parser) parseKey() (string, error) {
+	start := p.pos
+	for !p.done() && p.peek() != '.' && p.peek() != '[' {
+		if c := p.peek(); c == ']' || c == '\'' || c == '"' || c == '$' || c == ' ' || c == '\t' {
+			return "", p.errorf("unexpected character %q in key", c)
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a key")
+	}
+	return string(p.input[start:p.pos]), nil
+}
+
+// parseBracket parses an array index like `[0]` or a quoted key like
+// `['a.b']`.
+func (p *parser) parseBracket() (Segment, error) {
+	start := p.pos
+	p.pos++
+	if p.done() {
+		return Segment{}, p.errorf("expected an index or a quoted key")
+	}
+
+	var segment Segment
+	if quote := p.peek(); quote == '\'' || quote == '"' {
+		p.pos++
+		var key strings.Builder
+		for {
+			if p.done() {
+				p.pos = start
+				return Segment{}, p.errorf("unterminated quoted key")
+			}
+			c := p.peek()
+			p.pos++
+			if c == quote {
+				break
+			}
+			if c == '\\' && !p.done() {
+				c = p.peek()
+				p.pos++
+			}
+			key.WriteRune(c)
+		}
+		if key.Len() == 0 {
+			return Segment{}, p.errorf("empty quoted key")
+		}
+		segment = Segment{Key: key.String()}
+	} else {
+		digits := p.pos
+		for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		if p.pos == digits {
+			return Segment{}, p.errorf("expected an index or a quoted key")
+		}
+		index, err := strconv.Atoi(string(p.input[digits:p.pos]))
+		if err != nil {
+			p.pos = digits
+			return Segment{}, p.errorf("invalid index")
+		}
+		segment = Segment{Index: index, IsIndex: true}
+	}
+
+	if p.done() || p.peek() != ']' {
+		return Segment{}, p.errorf(`expected "]"`)
+	}
+	p.pos++
+	return segment, nil
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	return p.input[p.pos]
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Position: p.pos + 1, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,83 @@
+package jsonpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	segments, err := Parse(`$.items[0]['key.with.dots']["quoted \" key"].name`)
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{
+		{Key: "items"},
+		{Index: 0, IsIndex: true},
+		{Key: "key.with.dots"},
+		{Key: `quoted " key`},
+		{Key: "name"},
+	}, segments)
+
+	segments, err = Parse("[1].status")
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{{Index: 1, IsIndex: true}, {Key: "status"}}, segments)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"":           "expected a key at position 1",
+		"$":          `expected "." or "[" after "$" at position 2`,
+		"$status":    `unexpected character 's', expected "." or "[" at position 2`,
+		"a..b":       "expected a key at position 3",
+		"a.b.":       "expected a key at position 5",
+		"a[x]":       "expected an index or a quoted key at position 3",
+		"a[0":        `expected "]" at position 4`,
+		"a['b":       "unterminated quoted key at position 2",
+		"a['b'x]":    `expected "]" at position 6`,
+		"a b":        "unexpected character ' ' in key at position 2",
+		"items[''].": "empty quoted key at position 9",
+	}
+	for input, expected := range cases {
+		_, err := Parse(input)
+		var syntaxErr *SyntaxError
+		require.ErrorAs(t, err, &syntaxErr, input)
+		assert.Equal(t, expected, err.Error(), input)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for input, expected := range map[string]string{
+		"a.b":                "$.a.b",
+		"$.a.b":              "$.a.b",
+		"a['b'][0]":          "$.a.b[0]",
+		`$["key.with.dots"]`: "$['key.with.dots']",
+		"$['it\\'s']":        "$['it\\'s']",
+	} {
+		normalized, err := Normalize(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, normalized, input)
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	assert.True(t, Equivalent("$.a.b", "a.b"))
+	assert.True(t, Equivalent("a['b'][0]", "$.a.b[0]"))
+	assert.False(t, Equivalent("a.b", "a['b.c']"))
+	assert.False(t, Equivalent("a.b", "a..b"))
+}
+
+func TestValue(t *testing.T) {
+	ctx := context.Background()
+
+	equal, diags := NewValue("$.a.b").StringSemanticEquals(ctx, NewValue("a.b"))
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	resp := &xattr.ValidateAttributeResponse{}
+	NewValue("a..b").ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("path")}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "expected a key at position 3")
+}
@@ -0,0 +1,101 @@
+package jsonpath
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/labd/terraform-provider-folge/internal/stringtype"
+)
+
+var (
+	_ basetypes.StringTypable                    = Type{}
+	_ basetypes.StringValuableWithSemanticEquals = Value{}
+	_ xattr.ValidateableAttribute                = Value{}
+)
+
+// Type is the attribute type of JSON paths. Values are validated when the
+// configuration is validated, and equivalent paths like `$.a.b` and `a.b`
+// are considered equal.
+type Type struct {
+	basetypes.StringType
+}
+
+func (t Type) String() string {
+	return "jsonpath.Type"
+}
+
+func (t Type) Equal(o attr.Type) bool {
+	other, ok := o.(Type)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t Type) ValueType(_ context.Context) attr.Value {
+	return Value{}
+}
+
+func (t Type) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Value{StringValue: in}, nil
+}
+
+func (t Type) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringtype.ValueFromTerraform(ctx, t, in)
+}
+
+// Value is a JSON path.
+type Value struct {
+	basetypes.StringValue
+}
+
+// NewValue returns a known JSON path.
+func NewValue(path string) Value {
+	return Value{StringValue: basetypes.NewStringValue(path)}
+}
+
+// NewNull returns a null JSON path.
+func NewNull() Value {
+	return Value{StringValue: basetypes.NewStringNull()}
+}
+
+// NewUnknown returns an unknown JSON path.
+func NewUnknown() Value {
+	return Value{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v Value) Type(_ context.Context) attr.Type {
+	return Type{}
+}
+
+func (v Value) Equal(o attr.Value) bool {
+	other, ok := o.(Value)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true when both paths select the same
+// property.
+func (v Value) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	return stringtype.SemanticEquals(v, newValuable, Equivalent)
+}
+
+// ValidateAttribute checks that the value is a valid JSON path.
+func (v Value) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := Parse(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON path",
+			fmt.Sprintf("The path %q is not a valid JSON path: %s.", v.ValueString(), err))
+	}
+}
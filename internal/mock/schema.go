@@ -0,0 +1,132 @@
+package mock
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// objectType describes the fields accepted for one discriminator value.
+type objectType struct {
+	schema   string
+	fields   []string
+	required []string
+}
+
+var checkTypes = map[string]objectType{
+	"http-status": {
+		schema:   "HttpStatusCheckTypedRequest",
+		fields:   []string{"label", "enabled", "crontab", "status_code"},
+		required: []string{"label", "status_code"},
+	},
+	"json-value": {
+		schema:   "JsonDataCheckTypedRequest",
+		fields:   []string{"label", "enabled", "crontab", "datatype", "operator", "path", "value"},
+		required: []string{"label", "datatype", "operator", "path", "value"},
+	},
+}
+
+var dataSourceTypes = map[string]objectType{
+	"http": {
+		schema:   "HttpDataSourceTypedRequest",
+		fields:   []string{"label", "url", "enabled", "basic_auth_username", "basic_auth_password"},
+		required: []string{"label", "url"},
+	},
+}
+
+// enums are the values of the enum fields in the OpenAPI document of the
+// server.
+var enums = map[string][]string{
+	"datatype": {"bool", "int", "str", "datetime"},
+	"operator": {"eq", "neq", "gt", "lt"},
+}
+
+// validate checks the object against the type registry and returns a copy
+// which only contains the known fields.
+func validate(types map[string]objectType, enums map[string][]string, data map[string]any) (map[string]any, error) {
+	discriminator, ok := data["type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing discriminator field type")
+	}
+	t, ok := types[discriminator]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", discriminator)
+	}
+
+	for _, field := range t.required {
+		if _, ok := data[field]; !ok {
+			return nil, fmt.Errorf("missing required field %s", field)
+		}
+	}
+
+	result := map[string]any{"type": discriminator}
+	for _, field := range t.fields {
+		value, ok := data[field]
+		if !ok {
+			continue
+		}
+		if allowed, ok := enums[field]; ok && !slices.Contains(allowed, fmt.Sprint(value)) {
+			return nil, fmt.Errorf("invalid value %v for field %s", value, field)
+		}
+		result[field] = value
+	}
+	return result, nil
+}
+
+// openapi returns a minimal OpenAPI document describing the types and fields
+// supported by the server, so the provider capability detection works as well.
+func (s *Server) openapi() map[string]any {
+	schemas := map[string]any{
+		"Check":      discriminator(s.checkTypes),
+		"DataSource": discriminator(s.dataSourceTypes),
+	}
+	for _, types := range []map[string]objectType{s.checkTypes, s.dataSourceTypes} {
+		for _, t := range types {
+			properties := map[string]any{"type": map[string]any{"type": "string"}}
+			for _, field := range t.fields {
+				properties[field] = map[string]any{}
+			}
+			schemas[t.schema] = map[string]any{
+				"type":       "object",
+				"properties": properties,
+			}
+		}
+	}
+	schemas["DatatypeEnum"] = map[string]any{"type": "string", "enum": s.enums["datatype"]}
+	schemas["OperatorEnum"] = map[string]any{"type": "string", "enum": s.enums["operator"]}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Folge mock",
+			"version": "mock",
+		},
+		"paths": map[string]any{},
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+}
+
+func discriminator(types map[string]objectType) map[string]any {
+	keys := make([]string, 0, len(types))
+	for key := range types {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mapping := map[string]any{}
+	oneOf := []any{}
+	for _, key := range keys {
+		ref := "#/components/schemas/" + types[key].schema
+		mapping[key] = ref
+		oneOf = append(oneOf, map[string]any{"$ref": ref})
+	}
+	return map[string]any{
+		"oneOf": oneOf,
+		"discriminator": map[string]any{
+			"propertyName": "type",
+			"mapping":      mapping,
+		},
+	}
+}
@@ -0,0 +1,491 @@
+// Package mock provides an in-process fake of the Folge API, so the provider
+// can be used without a Folge account, e.g. in CI.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Scheme is the URL scheme used to select the mock backend, e.g. `mock://` or
+// `mock:///tmp/folge.json` to persist the state in a file.
+const Scheme = "mock://"
+
+// Server implements the application, datasource and check endpoints of the
+// Folge API. It can be used as an http.RoundTripper, so no network
+// connection is needed.
+type Server struct {
+	mu    sync.Mutex
+	file  string
+	state *store
+	mux   *http.ServeMux
+
+	checkTypes      map[string]objectType
+	dataSourceTypes map[string]objectType
+	enums           map[string][]string
+}
+
+// Option configures a server.
+type Option func(s *Server)
+
+// WithCheckType adds a check type with the name of its request schema and its
+// fields, to mimic a server version supporting check types the provider
+// doesn't know yet.
+func WithCheckType(name string, schema string, fields []string, required []string) Option {
+	return func(s *Server) {
+		s.checkTypes[name] = objectType{schema: schema, fields: fields, required: required}
+	}
+}
+
+// WithEnumValues adds values to an enum field like `datatype`, to mimic a
+// server version supporting more values than the OpenAPI document of the
+// provider.
+func WithEnumValues(field string, values ...string) Option {
+	return func(s *Server) {
+		s.enums[field] = append(slices.Clone(s.enums[field]), values...)
+	}
+}
+
+// IsMockURL reports whether the URL selects the mock backend.
+func IsMockURL(url string) bool {
+	return strings.HasPrefix(url, Scheme)
+}
+
+// NewFromURL returns a server for a `mock://` URL. The path of the URL, if
+// any, is the file in which the state is persisted.
+func NewFromURL(url string) (*Server, error) {
+	return New(strings.TrimPrefix(url, Scheme))
+}
+
+// New returns a server which persists its state in file, or only keeps it in
+// memory when file is empty.
+func New(file string, opts ...Option) (*Server, error) {
+	s := &Server{
+		file:            file,
+		state:           newStore(),
+		checkTypes:      maps.Clone(checkTypes),
+		dataSourceTypes: maps.Clone(dataSourceTypes),
+		enums:           maps.Clone(enums),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if file != "" {
+		state, err := loadStore(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not load mock state from %s: %w", file, err)
+		}
+		s.state = state
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/openapi.yaml", s.getOpenAPI)
+	mux.HandleFunc("GET /api/applications/{$}", s.listApplications)
+	mux.HandleFunc("POST /api/applications/{$}", s.createApplication)
+	mux.HandleFunc("GET /api/applications/{app}/{$}", s.retrieveApplication)
+	mux.HandleFunc("PUT /api/applications/{app}/{$}", s.updateApplication)
+	mux.HandleFunc("DELETE /api/applications/{app}/{$}", s.destroyApplication)
+	mux.HandleFunc("GET /api/applications/{app}/data-sources/{$}", s.listDataSources)
+	mux.HandleFunc("POST /api/applications/{app}/data-sources/{$}", s.createDataSource)
+	mux.HandleFunc("GET /api/applications/{app}/data-sources/{ds}/{$}", s.retrieveDataSource)
+	mux.HandleFunc("PUT /api/applications/{app}/data-sources/{ds}/{$}", s.updateDataSource)
+	mux.HandleFunc("DELETE /api/applications/{app}/data-sources/{ds}/{$}", s.destroyDataSource)
+	mux.HandleFunc("GET /api/applications/{app}/data-sources/{ds}/checks/{$}", s.listChecks)
+	mux.HandleFunc("POST /api/applications/{app}/data-sources/{ds}/checks/{$}", s.createCheck)
+	mux.HandleFunc("GET /api/applications/{app}/data-sources/{ds}/checks/{check}/{$}", s.retrieveCheck)
+	mux.HandleFunc("PUT /api/applications/{app}/data-sources/{ds}/checks/{check}/{$}", s.updateCheck)
+	mux.HandleFunc("DELETE /api/applications/{app}/data-sources/{ds}/checks/{check}/{$}", s.destroyCheck)
+	s.mux = mux
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mux.ServeHTTP(w, r)
+}
+
+// RoundTrip implements http.RoundTripper by serving the request in-process.
+func (s *Server) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, r)
+
+	res := rec.Result()
+	res.Request = r
+	return res, nil
+}
+
+// Client returns an http.Client which sends all requests to the server.
+func (s *Server) Client() *http.Client {
+	return &http.Client{Transport: s}
+}
+
+// commit persists the state after a modification.
+func (s *Server) commit(w http.ResponseWriter) bool {
+	if s.file == "" {
+		return true
+	}
+	if err := s.state.save(s.file); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	return true
+}
+
+func (s *Server) getOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.openapi())
+}
+
+func (s *Server) listApplications(w http.ResponseWriter, _ *http.Request) {
+	result := []any{}
+	for _, id := range slices.Sorted(maps.Keys(s.state.Applications)) {
+		result = append(result, s.applicationJSON(s.state.Applications[id]))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createApplication(w http.ResponseWriter, r *http.Request) {
+	name, ok := readApplication(w, r)
+	if !ok {
+		return
+	}
+
+	app := &application{
+		ID:          s.state.nextID("application"),
+		Identifier:  uuid.NewString(),
+		Name:        name,
+		DataSources: map[int]*dataSource{},
+	}
+	s.state.Applications[app.ID] = app
+	if s.commit(w) {
+		writeJSON(w, http.StatusCreated, s.applicationJSON(app))
+	}
+}
+
+func (s *Server) retrieveApplication(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.findApplication(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.applicationJSON(app))
+}
+
+func (s *Server) updateApplication(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.findApplication(w, r)
+	if !ok {
+		return
+	}
+	name, ok := readApplication(w, r)
+	if !ok {
+		return
+	}
+
+	app.Name = name
+	if s.commit(w) {
+		writeJSON(w, http.StatusOK, s.applicationJSON(app))
+	}
+}
+
+func (s *Server) destroyApplication(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.findApplication(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.state.Applications, app.ID)
+	if s.commit(w) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) listDataSources(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.findApplication(w, r)
+	if !ok {
+		return
+	}
+
+	result := []any{}
+	for _, id := range slices.Sorted(maps.Keys(app.DataSources)) {
+		result = append(result, app.DataSources[id].Data)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createDataSource(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.findApplication(w, r)
+	if !ok {
+		return
+	}
+	data, ok := s.readObject(w, r, s.dataSourceTypes)
+	if !ok {
+		return
+	}
+
+	id := s.state.nextID("datasource")
+	data["id"] = id
+	if _, ok := data["enabled"]; !ok {
+		data["enabled"] = true
+	}
+	if app.DataSources == nil {
+		app.DataSources = map[int]*dataSource{}
+	}
+	app.DataSources[id] = &dataSource{Data: data, Checks: map[int]map[string]any{}}
+	if s.commit(w) {
+		writeJSON(w, http.StatusCreated, data)
+	}
+}
+
+func (s *Server) retrieveDataSource(w http.ResponseWriter, r *http.Request) {
+	_, ds, ok := s.findDataSource(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, ds.Data)
+}
+
+func (s *Server) updateDataSource(w http.ResponseWriter, r *http.Request) {
+	_, ds, ok := s.findDataSource(w, r)
+	if !ok {
+		return
+	}
+	data, ok := s.readObject(w, r, s.dataSourceTypes)
+	if !ok {
+		return
+	}
+	if data["type"] != ds.Data["type"] {
+		writeError(w, http.StatusBadRequest, "the type of a datasource cannot be changed")
+		return
+	}
+
+	data["id"] = ds.Data["id"]
+	if _, ok := data["enabled"]; !ok {
+		data["enabled"] = ds.Data["enabled"]
+	}
+	ds.Data = data
+	if s.commit(w) {
+		writeJSON(w, http.StatusOK, data)
+	}
+}
+
+func (s *Server) destroyDataSource(w http.ResponseWriter, r *http.Request) {
+	app, ds, ok := s.findDataSource(w, r)
+	if !ok {
+		return
+	}
+
+	delete(app.DataSources, asInt(ds.Data["id"]))
+	if s.commit(w) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) listChecks(w http.ResponseWriter, r *http.Request) {
+	_, ds, ok := s.findDataSource(w, r)
+	if !ok {
+		return
+	}
+
+	result := []any{}
+	for _, id := range slices.Sorted(maps.Keys(ds.Checks)) {
+		result = append(result, ds.Checks[id])
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createCheck(w http.ResponseWriter, r *http.Request) {
+	_, ds, ok := s.findDataSource(w, r)
+	if !ok {
+		return
+	}
+	data, ok := s.readObject(w, r, s.checkTypes)
+	if !ok {
+		return
+	}
+
+	id := s.state.nextID("check")
+	data["id"] = id
+	if _, ok := data["enabled"]; !ok {
+		data["enabled"] = true
+	}
+	if ds.Checks == nil {
+		ds.Checks = map[int]map[string]any{}
+	}
+	ds.Checks[id] = data
+	if s.commit(w) {
+		writeJSON(w, http.StatusCreated, data)
+	}
+}
+
+func (s *Server) retrieveCheck(w http.ResponseWriter, r *http.Request) {
+	_, check, ok := s.findCheck(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, check)
+}
+
+func (s *Server) updateCheck(w http.ResponseWriter, r *http.Request) {
+	ds, check, ok := s.findCheck(w, r)
+	if !ok {
+		return
+	}
+	data, ok := s.readObject(w, r, s.checkTypes)
+	if !ok {
+		return
+	}
+	if data["type"] != check["type"] {
+		writeError(w, http.StatusBadRequest, "the type of a check cannot be changed")
+		return
+	}
+
+	id := asInt(check["id"])
+	data["id"] = id
+	if _, ok := data["enabled"]; !ok {
+		data["enabled"] = check["enabled"]
+	}
+	ds.Checks[id] = data
+	if s.commit(w) {
+		writeJSON(w, http.StatusOK, data)
+	}
+}
+
+func (s *Server) destroyCheck(w http.ResponseWriter, r *http.Request) {
+	ds, check, ok := s.findCheck(w, r)
+	if !ok {
+		return
+	}
+
+	delete(ds.Checks, asInt(check["id"]))
+	if s.commit(w) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) applicationJSON(app *application) map[string]any {
+	return map[string]any{
+		"id":          app.ID,
+		"identifier":  app.Identifier,
+		"name":        app.Name,
+		"datasources": fmt.Sprintf("/api/applications/%d/data-sources/", app.ID),
+	}
+}
+
+func (s *Server) findApplication(w http.ResponseWriter, r *http.Request) (*application, bool) {
+	id, ok := pathID(w, r, "app")
+	if !ok {
+		return nil, false
+	}
+	app, ok := s.state.Applications[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No Application matches the given query.")
+		return nil, false
+	}
+	return app, true
+}
+
+func (s *Server) findDataSource(w http.ResponseWriter, r *http.Request) (*application, *dataSource, bool) {
+	app, ok := s.findApplication(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	id, ok := pathID(w, r, "ds")
+	if !ok {
+		return nil, nil, false
+	}
+	ds, ok := app.DataSources[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No DataSource matches the given query.")
+		return nil, nil, false
+	}
+	return app, ds, true
+}
+
+func (s *Server) findCheck(w http.ResponseWriter, r *http.Request) (*dataSource, map[string]any, bool) {
+	_, ds, ok := s.findDataSource(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	id, ok := pathID(w, r, "check")
+	if !ok {
+		return nil, nil, false
+	}
+	check, ok := ds.Checks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No Check matches the given query.")
+		return nil, nil, false
+	}
+	return ds, check, true
+}
+
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Invalid %s id %q.", name, r.PathValue(name)))
+		return 0, false
+	}
+	return id, true
+}
+
+func readApplication(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var body struct {
+		Name *string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	if body.Name == nil || *body.Name == "" {
+		writeError(w, http.StatusBadRequest, "missing required field name")
+		return "", false
+	}
+	return *body.Name, true
+}
+
+func (s *Server) readObject(w http.ResponseWriter, r *http.Request, types map[string]objectType) (map[string]any, bool) {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	data, err := validate(types, s.enums, body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return data, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+// asInt converts a numeric JSON value, which is a float64 after decoding, to
+// an int.
+func asInt(value any) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,149 @@
+package mock
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+func newClient(t *testing.T, s *Server) folge.ClientWithResponsesInterface {
+	client, err := folge.NewClientWithResponses("http://folge.mock", folge.WithHTTPClient(s.Client()))
+	require.NoError(t, err)
+	return client
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	s, err := New("")
+	require.NoError(t, err)
+	client := newClient(t, s)
+
+	app, err := client.ApplicationsCreateWithResponse(ctx, folge.ApplicationRequest{Name: "Checkout"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, app.StatusCode())
+	appId := *app.JSON201.Id
+	assert.NotNil(t, app.JSON201.Identifier)
+
+	dsReq := folge.DataSourceRequest{}
+	require.NoError(t, dsReq.FromHttpDataSourceTypedRequest(folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	}))
+	ds, err := client.ApplicationsDataSourcesCreateWithResponse(ctx, appId, dsReq)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, ds.StatusCode())
+	dsValue, err := ds.JSON201.AsHttpDataSourceTyped()
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/health", dsValue.Url)
+	dsId := *dsValue.Id
+
+	check := folge.Check{}
+	require.NoError(t, check.FromHttpStatusCheckTyped(folge.HttpStatusCheckTyped{
+		Label:      "Status",
+		StatusCode: 200,
+	}))
+	created, err := client.ApplicationsDataSourcesChecksCreateWithResponse(ctx, appId, dsId, folge.CheckRequest(check))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, created.StatusCode())
+	checkValue, err := created.JSON201.AsHttpStatusCheckTyped()
+	require.NoError(t, err)
+	assert.Equal(t, 200, checkValue.StatusCode)
+	assert.True(t, *checkValue.Enabled)
+
+	retrieved, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, *checkValue.Id)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retrieved.StatusCode())
+
+	// The check lives below the datasource, so other parents do not match
+	missing, err := client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId+1, *checkValue.Id)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, missing.StatusCode())
+
+	deleted, err := client.ApplicationsDestroyWithResponse(ctx, appId)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+
+	retrieved, err = client.ApplicationsDataSourcesChecksRetrieveWithResponse(ctx, appId, dsId, *checkValue.Id)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, retrieved.StatusCode())
+}
+
+func TestServerDiscriminator(t *testing.T) {
+	ctx := context.Background()
+	s, err := New("")
+	require.NoError(t, err)
+	client := newClient(t, s)
+
+	app, err := client.ApplicationsCreateWithResponse(ctx, folge.ApplicationRequest{Name: "Checkout"})
+	require.NoError(t, err)
+
+	dsReq := folge.DataSourceRequest{}
+	require.NoError(t, dsReq.FromHttpDataSourceTypedRequest(folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	}))
+	ds, err := client.ApplicationsDataSourcesCreateWithResponse(ctx, *app.JSON201.Id, dsReq)
+	require.NoError(t, err)
+	dsValue, err := ds.JSON201.AsHttpDataSourceTyped()
+	require.NoError(t, err)
+
+	check := folge.Check{}
+	require.NoError(t, check.FromJsonDataCheckTyped(folge.JsonDataCheckTyped{
+		Label:    "Value",
+		Datatype: "complex",
+		Operator: folge.Eq,
+		Path:     "a.b",
+		Value:    "1.0",
+	}))
+	created, err := client.ApplicationsDataSourcesChecksCreateWithResponse(ctx, *app.JSON201.Id, *dsValue.Id, folge.CheckRequest(check))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, created.StatusCode())
+
+	created, err = client.ApplicationsDataSourcesChecksCreateWithBodyWithResponse(
+		ctx, *app.JSON201.Id, *dsValue.Id, "application/json", strings.NewReader(`{"type": "other", "label": "x"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, created.StatusCode())
+}
+
+func TestServerEnumValues(t *testing.T) {
+	float := map[string]any{
+		"type": "json-value", "label": "Ratio", "datatype": "float", "operator": "lt", "path": "ratio", "value": "0.5",
+	}
+
+	// Only the values of the OpenAPI document are accepted by default
+	s, err := New("")
+	require.NoError(t, err)
+	_, err = validate(s.checkTypes, s.enums, float)
+	assert.EqualError(t, err, "invalid value float for field datatype")
+	assert.NotContains(t, s.enums["datatype"], "float")
+
+	s, err = New("", WithEnumValues("datatype", "float"))
+	require.NoError(t, err)
+	_, err = validate(s.checkTypes, s.enums, float)
+	assert.NoError(t, err)
+	assert.Contains(t, s.enums["datatype"], "float")
+	assert.NotContains(t, enums["datatype"], "float")
+}
+
+func TestServerPersistence(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := NewFromURL(Scheme + file)
+	require.NoError(t, err)
+	app, err := newClient(t, s).ApplicationsCreateWithResponse(ctx, folge.ApplicationRequest{Name: "Checkout"})
+	require.NoError(t, err)
+
+	s, err = NewFromURL(Scheme + file)
+	require.NoError(t, err)
+	retrieved, err := newClient(t, s).ApplicationsRetrieveWithResponse(ctx, *app.JSON201.Id)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, retrieved.StatusCode())
+	assert.Equal(t, "Checkout", retrieved.JSON200.Name)
+	assert.Equal(t, *app.JSON201.Identifier, *retrieved.JSON200.Identifier)
+}
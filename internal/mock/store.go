@@ -0,0 +1,64 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// store is the state of the mock backend. It is kept in memory and
+// optionally persisted to a file, so it survives between Terraform runs.
+type store struct {
+	Applications map[int]*application `json:"applications"`
+	NextIDs      map[string]int       `json:"next_ids"`
+}
+
+type application struct {
+	ID          int                 `json:"id"`
+	Identifier  string              `json:"identifier"`
+	Name        string              `json:"name"`
+	DataSources map[int]*dataSource `json:"data_sources"`
+}
+
+type dataSource struct {
+	Data   map[string]any         `json:"data"`
+	Checks map[int]map[string]any `json:"checks"`
+}
+
+func newStore() *store {
+	return &store{
+		Applications: map[int]*application{},
+		NextIDs:      map[string]int{},
+	}
+}
+
+// nextID returns a new ID for the kind of object. IDs are sequential per kind,
+// like the database sequences of the real API.
+func (s *store) nextID(kind string) int {
+	s.NextIDs[kind]++
+	return s.NextIDs[kind]
+}
+
+func loadStore(file string) (*store, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return newStore(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := newStore()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *store) save(file string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o600)
+}
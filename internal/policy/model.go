@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Model maps the provider policy block to a Go type.
+type Model struct {
+	MinCrontabInterval     *MinCrontabIntervalModel     `tfsdk:"min_crontab_interval"`
+	RequireHTTPS           *RuleModel                   `tfsdk:"require_https"`
+	RequireEnabledChecks   *RuleModel                   `tfsdk:"require_enabled_checks"`
+	MaxChecksPerDatasource *MaxChecksPerDatasourceModel `tfsdk:"max_checks_per_datasource"`
+	LabelPattern           *LabelPatternModel           `tfsdk:"label_pattern"`
+}
+
+type RuleModel struct {
+	Severity types.String `tfsdk:"severity"`
+}
+
+type MinCrontabIntervalModel struct {
+	Interval types.String `tfsdk:"interval"`
+	Severity types.String `tfsdk:"severity"`
+}
+
+type MaxChecksPerDatasourceModel struct {
+	Limit    types.Int64  `tfsdk:"limit"`
+	Severity types.String `tfsdk:"severity"`
+}
+
+type LabelPatternModel struct {
+	Pattern  types.String `tfsdk:"pattern"`
+	Severity types.String `tfsdk:"severity"`
+}
+
+// Schema returns the provider block used to configure the policy.
+func Schema() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "Organizational guardrails which are enforced when planning resources.",
+		Blocks: map[string]schema.Block{
+			"min_crontab_interval": schema.SingleNestedBlock{
+				Description: "Require checks to run at most once per interval.",
+				Attributes: map[string]schema.Attribute{
+					"interval": schema.StringAttribute{
+						Description: "The minimum interval between two runs, e.g. `5m`.",
+						Required:    true,
+					},
+					"severity": severityAttribute(),
+				},
+			},
+			"require_https": schema.SingleNestedBlock{
+				Description: "Require datasource URLs to use https.",
+				Attributes: map[string]schema.Attribute{
+					"severity": severityAttribute(),
+				},
+			},
+			"require_enabled_checks": schema.SingleNestedBlock{
+				Description: "Require checks to be enabled.",
+				Attributes: map[string]schema.Attribute{
+					"severity": severityAttribute(),
+				},
+			},
+			"max_checks_per_datasource": schema.SingleNestedBlock{
+				Description: "Limit the number of checks per datasource.",
+				Attributes: map[string]schema.Attribute{
+					"limit": schema.Int64Attribute{
+						Description: "The maximum number of checks.",
+						Required:    true,
+					},
+					"severity": severityAttribute(),
+				},
+			},
+			"label_pattern": schema.SingleNestedBlock{
+				Description: "Require application, datasource and check names to match a regular expression.",
+				Attributes: map[string]schema.Attribute{
+					"pattern": schema.StringAttribute{
+						Description: "The regular expression names must match.",
+						Required:    true,
+					},
+					"severity": severityAttribute(),
+				},
+			},
+		},
+	}
+}
+
+func severityAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "Either `error` or `warning`. Defaults to `error`.",
+		Optional:    true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(string(SeverityError), string(SeverityWarning)),
+		},
+	}
+}
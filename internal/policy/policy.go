@@ -0,0 +1,224 @@
+package policy
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Policy holds the rules configured on the provider. A nil Policy enforces
+// nothing.
+type Policy struct {
+	MinCrontabInterval     *MinCrontabIntervalRule
+	RequireHTTPS           *Rule
+	RequireEnabledChecks   *Rule
+	MaxChecksPerDatasource *MaxChecksPerDatasourceRule
+	LabelPattern           *LabelPatternRule
+}
+
+type Rule struct {
+	Severity Severity
+}
+
+type MinCrontabIntervalRule struct {
+	Rule
+	Interval time.Duration
+}
+
+type MaxChecksPerDatasourceRule struct {
+	Rule
+	Limit int
+}
+
+type LabelPatternRule struct {
+	Rule
+	Pattern *regexp.Regexp
+}
+
+// New converts the provider policy block into a Policy. Rules with unknown
+// values are skipped, since they cannot be enforced.
+func New(m *Model) (*Policy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m == nil {
+		return nil, diags
+	}
+
+	root := path.Root("policy")
+	p := &Policy{}
+
+	if r := m.MinCrontabInterval; r != nil && !r.Interval.IsUnknown() {
+		interval, err := time.ParseDuration(r.Interval.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				root.AtName("min_crontab_interval").AtName("interval"),
+				"Invalid policy interval",
+				fmt.Sprintf("Could not parse %q as a duration: %s", r.Interval.ValueString(), err),
+			)
+		} else {
+			p.MinCrontabInterval = &MinCrontabIntervalRule{
+				Rule:     newRule(r.Severity),
+				Interval: interval,
+			}
+		}
+	}
+
+	if r := m.RequireHTTPS; r != nil {
+		rule := newRule(r.Severity)
+		p.RequireHTTPS = &rule
+	}
+
+	if r := m.RequireEnabledChecks; r != nil {
+		rule := newRule(r.Severity)
+		p.RequireEnabledChecks = &rule
+	}
+
+	if r := m.MaxChecksPerDatasource; r != nil && !r.Limit.IsUnknown() {
+		p.MaxChecksPerDatasource = &MaxChecksPerDatasourceRule{
+			Rule:  newRule(r.Severity),
+			Limit: int(r.Limit.ValueInt64()),
+		}
+	}
+
+	if r := m.LabelPattern; r != nil && !r.Pattern.IsUnknown() {
+		pattern, err := regexp.Compile(r.Pattern.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				root.AtName("label_pattern").AtName("pattern"),
+				"Invalid policy pattern",
+				fmt.Sprintf("Could not compile %q: %s", r.Pattern.ValueString(), err),
+			)
+		} else {
+			p.LabelPattern = &LabelPatternRule{
+				Rule:    newRule(r.Severity),
+				Pattern: pattern,
+			}
+		}
+	}
+
+	return p, diags
+}
+
+func newRule(severity types.String) Rule {
+	if severity.IsNull() || severity.IsUnknown() {
+		return Rule{Severity: SeverityError}
+	}
+	return Rule{Severity: Severity(severity.ValueString())}
+}
+
+// report adds a violation to diags as either an error or a warning.
+func (r Rule) report(diags *diag.Diagnostics, p path.Path, detail string) {
+	summary := "Policy violation"
+	if r.Severity == SeverityWarning {
+		diags.AddAttributeWarning(p, summary, detail)
+		return
+	}
+	diags.AddAttributeError(p, summary, detail)
+}
+
+// ValidateCrontab checks the schedule of a check against the minimum interval.
+// Invalid crontabs are reported by the crontab type already, so they are
+// skipped.
+func (p *Policy) ValidateCrontab(attr path.Path, value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if p == nil || p.MinCrontabInterval == nil || value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	schedule, err := crontab.Parse(value.ValueString())
+	if err != nil {
+		return diags
+	}
+
+	// Schedules which run too rarely to sample two runs can't be too frequent
+	rule := p.MinCrontabInterval
+	if interval, ok := crontab.MinInterval(schedule); ok && interval < rule.Interval {
+		rule.report(&diags, attr, fmt.Sprintf(
+			"The crontab %q runs every %s, the policy requires an interval of at least %s.",
+			value.ValueString(), interval, rule.Interval))
+	}
+	return diags
+}
+
+// ValidateURL checks that a datasource URL uses https.
+func (p *Policy) ValidateURL(attr path.Path, value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if p == nil || p.RequireHTTPS == nil || value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	u, err := url.Parse(value.ValueString())
+	if err != nil || !strings.EqualFold(u.Scheme, "https") {
+		p.RequireHTTPS.report(&diags, attr, fmt.Sprintf(
+			"The URL %q does not use https, which is required by the policy.", value.ValueString()))
+	}
+	return diags
+}
+
+// ValidateEnabled checks that a check is enabled.
+func (p *Policy) ValidateEnabled(attr path.Path, value types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if p == nil || p.RequireEnabledChecks == nil || value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	if !value.ValueBool() {
+		p.RequireEnabledChecks.report(&diags, attr,
+			"The check is disabled, the policy requires checks to be enabled.")
+	}
+	return diags
+}
+
+// ValidateLabel checks that a name matches the configured pattern.
+func (p *Policy) ValidateLabel(attr path.Path, value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if p == nil || p.LabelPattern == nil || value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	rule := p.LabelPattern
+	if !rule.Pattern.MatchString(value.ValueString()) {
+		rule.report(&diags, attr, fmt.Sprintf(
+			"The name %q does not match the pattern %q required by the policy.",
+			value.ValueString(), rule.Pattern.String()))
+	}
+	return diags
+}
+
+// ValidateCheckCount checks that adding a check to a datasource which has
+// count checks, including the ones added earlier in the same plan, does not
+// exceed the limit.
+func (p *Policy) ValidateCheckCount(attr path.Path, datasourceId int, count int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if p == nil || p.MaxChecksPerDatasource == nil {
+		return diags
+	}
+
+	rule := p.MaxChecksPerDatasource
+	if count+1 > rule.Limit {
+		rule.report(&diags, attr, fmt.Sprintf(
+			"Datasource %d already has %d checks including the ones added in this plan, the policy allows at most %d.",
+			datasourceId, count, rule.Limit))
+	}
+	return diags
+}
+
+// HasCheckCount reports whether the number of checks needs to be validated,
+// so callers can skip listing them otherwise.
+func (p *Policy) HasCheckCount() bool {
+	return p != nil && p.MaxChecksPerDatasource != nil
+}
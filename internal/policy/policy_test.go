@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNilPolicy(t *testing.T) {
+	var p *Policy
+
+	assert.False(t, p.ValidateCrontab(path.Root("crontab"), types.StringValue("* * * * *")).HasError())
+	assert.False(t, p.ValidateURL(path.Root("url"), types.StringValue("http://example.com")).HasError())
+	assert.False(t, p.ValidateEnabled(path.Root("enabled"), types.BoolValue(false)).HasError())
+	assert.False(t, p.ValidateLabel(path.Root("name"), types.StringValue("x")).HasError())
+	assert.False(t, p.ValidateCheckCount(path.Root("datasource_id"), 1, 100).HasError())
+}
+
+func TestValidateCrontab(t *testing.T) {
+	p, diags := New(&Model{
+		MinCrontabInterval: &MinCrontabIntervalModel{
+			Interval: types.StringValue("5m"),
+			Severity: types.StringNull(),
+		},
+	})
+	require.False(t, diags.HasError())
+
+	tests := []struct {
+		crontab string
+		error   bool
+	}{
+		{"*/5 * * * *", false},
+		{"@hourly", false},
+		{"0 9 * * 1-5", false},
+		{"* * * * *", true},
+		{"*/2 * * * *", true},
+		{"0,1 9 * * *", true},
+		// Reported by the crontab type instead
+		{"*/5 * * *", false},
+		{"0 0 29 2 *", false},
+		{"@yearly", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.crontab, func(t *testing.T) {
+			diags := p.ValidateCrontab(path.Root("crontab"), types.StringValue(tt.crontab))
+			assert.Equal(t, tt.error, diags.HasError())
+		})
+	}
+
+	assert.False(t, p.ValidateCrontab(path.Root("crontab"), types.StringUnknown()).HasError())
+}
+
+func TestSeverity(t *testing.T) {
+	p, diags := New(&Model{
+		RequireHTTPS:         &RuleModel{Severity: types.StringValue("warning")},
+		RequireEnabledChecks: &RuleModel{Severity: types.StringNull()},
+	})
+	require.False(t, diags.HasError())
+
+	diags = p.ValidateURL(path.Root("url"), types.StringValue("http://example.com"))
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 1, diags.WarningsCount())
+
+	diags = p.ValidateURL(path.Root("url"), types.StringValue("https://example.com"))
+	assert.Empty(t, diags)
+
+	diags = p.ValidateEnabled(path.Root("enabled"), types.BoolValue(false))
+	assert.True(t, diags.HasError())
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+}
+
+func TestValidateLabelAndCount(t *testing.T) {
+	p, diags := New(&Model{
+		LabelPattern: &LabelPatternModel{
+			Pattern:  types.StringValue("^team-"),
+			Severity: types.StringNull(),
+		},
+		MaxChecksPerDatasource: &MaxChecksPerDatasourceModel{
+			Limit:    types.Int64Value(2),
+			Severity: types.StringNull(),
+		},
+	})
+	require.False(t, diags.HasError())
+
+	assert.False(t, p.ValidateLabel(path.Root("name"), types.StringValue("team-checkout")).HasError())
+	assert.True(t, p.ValidateLabel(path.Root("name"), types.StringValue("checkout")).HasError())
+
+	assert.True(t, p.HasCheckCount())
+	assert.False(t, p.ValidateCheckCount(path.Root("datasource_id"), 1, 1).HasError())
+	assert.True(t, p.ValidateCheckCount(path.Root("datasource_id"), 1, 2).HasError())
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, diags := New(&Model{
+		MinCrontabInterval: &MinCrontabIntervalModel{
+			Interval: types.StringValue("five minutes"),
+			Severity: types.StringNull(),
+		},
+		LabelPattern: &LabelPatternModel{
+			Pattern:  types.StringValue("("),
+			Severity: types.StringNull(),
+		},
+	})
+	assert.Equal(t, 2, diags.ErrorsCount())
+}
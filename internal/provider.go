@@ -18,10 +18,16 @@ import (
 	"gopkg.in/dnaeon/go-vcr.v3/recorder"
 
 	"github.com/labd/terraform-provider-folge/internal/application"
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
+	"github.com/labd/terraform-provider-folge/internal/check"
 	checkhttpstatus "github.com/labd/terraform-provider-folge/internal/check_http_status"
 	checkjsonproperty "github.com/labd/terraform-provider-folge/internal/check_json_property"
+	"github.com/labd/terraform-provider-folge/internal/crontab"
 	folge_datasource "github.com/labd/terraform-provider-folge/internal/datasource"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/mock"
+	"github.com/labd/terraform-provider-folge/internal/policy"
+	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -73,6 +79,14 @@ func WithRecorderClient(file string, mode recorder.Mode) (OptionFunc, func() err
 	}, stop
 }
 
+// WithClient makes the provider use the given client instead of creating one
+// from the configuration, e.g. to inject a fake client in tests.
+func WithClient(client folge.ClientWithResponsesInterface) OptionFunc {
+	return func(p *folgeProvider) {
+		p.client = client
+	}
+}
+
 // New is a helper function to simplify provider server and testing implementation.
 func New(opts ...OptionFunc) provider.Provider {
 	tp := http.DefaultTransport
@@ -91,13 +105,22 @@ func New(opts ...OptionFunc) provider.Provider {
 // folgeProvider is the provider implementation.
 type folgeProvider struct {
 	httpClient *http.Client
+	client     folge.ClientWithResponsesInterface
 }
 
 // folgeProviderModel maps provider schema data to a Go type.
 type folgeProviderModel struct {
-	URL          types.String `tfsdk:"url"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
+	URL          types.String   `tfsdk:"url"`
+	ClientID     types.String   `tfsdk:"client_id"`
+	ClientSecret types.String   `tfsdk:"client_secret"`
+	Policy       *policy.Model  `tfsdk:"policy"`
+	Defaults     *defaultsModel `tfsdk:"defaults"`
+}
+
+// defaultsModel maps the provider defaults block to a Go type.
+type defaultsModel struct {
+	Crontab crontab.Value `tfsdk:"crontab"`
+	Enabled types.Bool    `tfsdk:"enabled"`
 }
 
 // Metadata returns the provider type name.
@@ -111,7 +134,7 @@ func (p *folgeProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 		Description: "Interact with Folge.",
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				Description: "Management API base URL",
+				Description: "Management API base URL. Use `mock://` to use an in-memory fake of the API, or `mock:///path/to/state.json` to persist its state in a file. Setting the `FOLGE_MOCK` environment variable selects `mock://` as well.",
 				Optional:    true,
 			},
 			"client_id": schema.StringAttribute{
@@ -125,6 +148,23 @@ func (p *folgeProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Sensitive:   true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"policy": policy.Schema(),
+			"defaults": schema.SingleNestedBlock{
+				Description: "Default settings for checks which do not configure them.",
+				Attributes: map[string]schema.Attribute{
+					"crontab": schema.StringAttribute{
+						Description: "The default crontab.",
+						Optional:    true,
+						CustomType:  crontab.Type{},
+					},
+					"enabled": schema.BoolAttribute{
+						Description: "Whether checks are enabled by default.",
+						Optional:    true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -170,38 +210,93 @@ func (p *folgeProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		url = "https://app.folge.io"
 	}
 
+	if os.Getenv("FOLGE_MOCK") != "" && !mock.IsMockURL(url) {
+		url = mock.Scheme
+	}
+
+	// The mock backend serves the requests in-process instead of over the
+	// network.
+	httpClient := p.httpClient
+	if mock.IsMockURL(url) {
+		server, err := mock.NewFromURL(url)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Create Folge Mock Backend", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Using the Folge mock backend")
+		httpClient = server.Client()
+		url = "http://folge.mock"
+	}
+
 	ctx = tflog.SetField(ctx, "folge_url", url)
 	ctx = tflog.SetField(ctx, "folge_client_id", clientId)
 	ctx = tflog.SetField(ctx, "folge_client_secret", clientSecret)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "folge_client_secret")
 
-	tflog.Debug(ctx, "Creating Folge client")
+	rules, diags := policy.New(config.Policy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	apiKeyProvider, err := securityprovider.NewSecurityProviderBasicAuth(clientId, clientSecret)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Folge API Client", err.Error())
+	defaults := &utils.CheckDefaults{
+		Crontab: crontab.NewNull(),
+		Enabled: types.BoolNull(),
+	}
+	if config.Defaults != nil {
+		defaults.Crontab = config.Defaults.Crontab
+		defaults.Enabled = config.Defaults.Enabled
 	}
 
-	// Create a new Folge client using the configuration values
-	client, err := folge.NewClientWithResponses(
-		url,
-		folge.WithHTTPClient(p.httpClient),
-		folge.WithRequestEditorFn(apiKeyProvider.Intercept))
+	data := &utils.ProviderData{
+		Client:   p.client,
+		URL:      url,
+		Policy:   rules,
+		Defaults: defaults,
+		Cache:    utils.NewCache(),
+	}
 
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Folge API Client",
-			"An unexpected error occurred when creating the Folge API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Folge Client Error: "+err.Error(),
-		)
-		return
+	// An injected client is used as-is, which is mostly useful for tests
+	if data.Client == nil {
+		tflog.Debug(ctx, "Creating Folge client")
+
+		apiKeyProvider, err := securityprovider.NewSecurityProviderBasicAuth(clientId, clientSecret)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Create Folge API Client", err.Error())
+			return
+		}
+
+		// Create a new Folge client using the configuration values
+		client, err := folge.NewClientWithResponses(
+			url,
+			folge.WithHTTPClient(httpClient),
+			folge.WithRequestEditorFn(apiKeyProvider.Intercept))
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Folge API Client",
+				"An unexpected error occurred when creating the Folge API client. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Folge Client Error: "+err.Error(),
+			)
+			return
+		}
+		data.Client = client
+
+		// Detect which features the server supports. Servers which do not expose
+		// their OpenAPI document are assumed to support everything.
+		caps, err := capabilities.Detect(ctx, httpClient, url, apiKeyProvider.Intercept)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to detect Folge server capabilities", map[string]any{"error": err.Error()})
+		} else {
+			data.Capabilities = caps
+		}
 	}
 
 	// Make the Folge client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "Configured Folge client", map[string]any{"success": true})
 }
@@ -218,5 +313,6 @@ func (p *folgeProvider) Resources(_ context.Context) []func() resource.Resource
 		folge_datasource.NewDataSourceResource,
 		checkhttpstatus.NewCheckHttpStatusResource,
 		checkjsonproperty.NewCheckJsonPropertyResource,
+		check.NewCheckResource,
 	}
 }
@@ -1,9 +1,17 @@
 package internal
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/utils"
 )
 
 func TestProvider(t *testing.T) {
@@ -12,3 +20,60 @@ func TestProvider(t *testing.T) {
 	assert.NotNil(t, p)
 
 }
+
+// fakeClient only needs to satisfy the interface, calling any method panics.
+type fakeClient struct {
+	folge.ClientWithResponsesInterface
+}
+
+// configure runs Configure on the provider with an empty configuration.
+func configure(t *testing.T, p provider.Provider) *provider.ConfigureResponse {
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objType, values),
+		},
+	}, resp)
+	return resp
+}
+
+func TestProviderWithClient(t *testing.T) {
+	client := &fakeClient{}
+	resp := configure(t, New(WithClient(client)))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	data, diags := utils.GetProviderData(resp.ResourceData)
+	require.False(t, diags.HasError())
+	assert.Same(t, client, data.Client)
+	assert.NotNil(t, data.Cache)
+}
+
+func TestProviderWithMock(t *testing.T) {
+	t.Setenv("FOLGE_URL", "mock://")
+
+	resp := configure(t, New())
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	data, diags := utils.GetProviderData(resp.ResourceData)
+	require.False(t, diags.HasError())
+	require.NotNil(t, data.Capabilities)
+	assert.Equal(t, "mock", data.Capabilities.Version)
+}
+
+func TestGetProviderDataInvalid(t *testing.T) {
+	_, diags := utils.GetProviderData("invalid")
+	assert.True(t, diags.HasError())
+}
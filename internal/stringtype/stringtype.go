@@ -0,0 +1,56 @@
+// Package stringtype holds the plumbing shared by the custom string types of
+// the provider, which only differ in how their values are validated and
+// compared.
+package stringtype
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Value is implemented by the values of custom string types.
+type Value interface {
+	basetypes.StringValuable
+	ValueString() string
+}
+
+// ValueFromTerraform converts a Terraform value to a value of the custom
+// string type t.
+func ValueFromTerraform(ctx context.Context, t basetypes.StringTypable, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := basetypes.StringType{}.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// SemanticEquals reports whether equivalent considers both values equal. The
+// new value must be of the same type as v.
+func SemanticEquals[V Value](v V, newValuable basetypes.StringValuable, equivalent func(a, b string) bool) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(V)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	return equivalent(v.ValueString(), newValue.ValueString()), diags
+}
@@ -0,0 +1,40 @@
+package stringtype_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/jsonpath"
+	"github.com/labd/terraform-provider-folge/internal/stringtype"
+)
+
+func TestValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := stringtype.ValueFromTerraform(ctx, jsonpath.Type{}, tftypes.NewValue(tftypes.String, "$.a"))
+	require.NoError(t, err)
+	assert.Equal(t, jsonpath.NewValue("$.a"), value)
+
+	value, err = stringtype.ValueFromTerraform(ctx, jsonpath.Type{}, tftypes.NewValue(tftypes.String, nil))
+	require.NoError(t, err)
+	assert.Equal(t, jsonpath.NewNull(), value)
+
+	_, err = stringtype.ValueFromTerraform(ctx, jsonpath.Type{}, tftypes.NewValue(tftypes.Bool, true))
+	assert.Error(t, err)
+}
+
+func TestSemanticEquals(t *testing.T) {
+	equal, diags := stringtype.SemanticEquals(jsonpath.NewValue("$.a"), jsonpath.NewValue("a"), jsonpath.Equivalent)
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	// Values of another type are reported instead of compared
+	equal, diags = stringtype.SemanticEquals(jsonpath.NewValue("$.a"), types.StringValue("$.a"), jsonpath.Equivalent)
+	assert.True(t, diags.HasError())
+	assert.False(t, equal)
+}
@@ -0,0 +1,257 @@
+// Package testutils contains helpers to test resources against the mock
+// backend without running Terraform.
+package testutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/mock"
+	"github.com/labd/terraform-provider-folge/internal/utils"
+)
+
+// NewClient returns a client for a new, empty mock backend.
+func NewClient(t *testing.T, opts ...mock.Option) folge.ClientWithResponsesInterface {
+	t.Helper()
+
+	server, err := mock.New("", opts...)
+	require.NoError(t, err)
+
+	client, err := folge.NewClientWithResponses("http://folge.mock", folge.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	return client
+}
+
+// WithResponseTimeCheck adds a `response-time` check type to the mock backend,
+// which is unknown to the provider, to test managing it with raw_json.
+func WithResponseTimeCheck() mock.Option {
+	return mock.WithCheckType("response-time", "ResponseTimeCheckTypedRequest",
+		[]string{"label", "enabled", "crontab", "max_milliseconds", "percentile"},
+		[]string{"label", "max_milliseconds"})
+}
+
+// DetectCapabilities returns the capabilities the provider detects from a mock
+// backend with the options.
+func DetectCapabilities(t *testing.T, opts ...mock.Option) *capabilities.Capabilities {
+	t.Helper()
+
+	server, err := mock.New("", opts...)
+	require.NoError(t, err)
+
+	c, err := capabilities.Detect(context.Background(), server.Client(), "http://folge.mock", nil)
+	require.NoError(t, err)
+	return c
+}
+
+// NewClientWithoutCrontab returns a client for a new, empty mock backend which
+// removes the crontab from its responses, like servers which don't support
+// schedules.
+func NewClientWithoutCrontab(t *testing.T) folge.ClientWithResponsesInterface {
+	t.Helper()
+
+	server, err := mock.New("")
+	require.NoError(t, err)
+
+	client, err := folge.NewClientWithResponses("http://folge.mock",
+		folge.WithHTTPClient(&http.Client{Transport: dropCrontab{server}}))
+	require.NoError(t, err)
+	return client
+}
+
+// dropCrontab is a transport which removes the crontab from the responses of
+// the mock.
+type dropCrontab struct {
+	server *mock.Server
+}
+
+func (d dropCrontab) RoundTrip(r *http.Request) (*http.Response, error) {
+	res, err := d.server.RoundTrip(r)
+	if err != nil {
+		return res, err
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]any
+	if json.Unmarshal(body, &data) == nil {
+		delete(data, "crontab")
+		if body, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	return res, nil
+}
+
+// Configure configures the resource with the client and returns its schema.
+func Configure(t *testing.T, r resource.Resource, client folge.ClientWithResponsesInterface) schema.Schema {
+	t.Helper()
+	ctx := context.Background()
+
+	if c, ok := r.(resource.ResourceWithConfigure); ok {
+		resp := &resource.ConfigureResponse{}
+		c.Configure(ctx, resource.ConfigureRequest{
+			ProviderData: &utils.ProviderData{
+				Client: client,
+				Cache:  utils.NewCache(),
+			},
+		}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	}
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return resp.Schema
+}
+
+// State returns the state of a resource holding the model.
+func State(t *testing.T, s schema.Schema, model any) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+	diags := state.Set(context.Background(), model)
+	require.False(t, diags.HasError(), diags)
+	return state
+}
+
+// ConfigDiff returns the names of the attributes and blocks whose value in the
+// state differs from the configuration, which Terraform plans to change after
+// a refresh. Computed attributes keep their state when they aren't
+// configured, so they are left out then.
+func ConfigDiff(t *testing.T, s schema.Schema, state tfsdk.State, config any) []string {
+	t.Helper()
+
+	configRaw := State(t, s, config).Raw
+	diffs, err := configRaw.Diff(state.Raw)
+	require.NoError(t, err)
+
+	var names []string
+	for _, d := range diffs {
+		steps := d.Path.Steps()
+		if len(steps) == 0 {
+			continue
+		}
+		name, ok := steps[0].(tftypes.AttributeName)
+		if !ok || slices.Contains(names, string(name)) {
+			continue
+		}
+		if attribute, ok := s.Attributes[string(name)]; ok && attribute.IsComputed() {
+			value, _, err := tftypes.WalkAttributePath(configRaw, tftypes.NewAttributePath().WithAttributeName(string(name)))
+			require.NoError(t, err)
+			if v, ok := value.(tftypes.Value); !attribute.IsOptional() || (ok && v.IsNull()) {
+				continue
+			}
+		}
+		names = append(names, string(name))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Create creates the resource from the planned model like Terraform does
+// during an apply.
+func Create(t *testing.T, r resource.Resource, s schema.Schema, model any) *resource.CreateResponse {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: s, Raw: State(t, s, model).Raw}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{
+			Schema: s,
+			Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+		},
+	}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	return resp
+}
+
+// Update updates the resource from the planned model like Terraform does
+// during an apply.
+func Update(t *testing.T, r resource.Resource, s schema.Schema, state tfsdk.State, model any) *resource.UpdateResponse {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: s, Raw: State(t, s, model).Raw}
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: state}, resp)
+	return resp
+}
+
+// Read refreshes the state like Terraform does during a plan.
+func Read(t *testing.T, r resource.Resource, state tfsdk.State) *resource.ReadResponse {
+	t.Helper()
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+// Delete destroys the resource like Terraform does during an apply.
+func Delete(t *testing.T, r resource.Resource, state tfsdk.State) *resource.DeleteResponse {
+	t.Helper()
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+	return resp
+}
+
+// CreateApplication creates an application and returns its ID.
+func CreateApplication(t *testing.T, client folge.ClientWithResponsesInterface, name string) int {
+	t.Helper()
+
+	content, err := client.ApplicationsCreateWithResponse(context.Background(), folge.ApplicationRequest{Name: name})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, content.StatusCode(), string(content.Body))
+	return *content.JSON201.Id
+}
+
+// CreateDataSource creates an http datasource and returns its ID.
+func CreateDataSource(t *testing.T, client folge.ClientWithResponsesInterface, applicationId int, data folge.HttpDataSourceTypedRequest) int {
+	t.Helper()
+
+	req := folge.DataSourceRequest{}
+	require.NoError(t, req.FromHttpDataSourceTypedRequest(data))
+	content, err := client.ApplicationsDataSourcesCreateWithResponse(context.Background(), applicationId, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, content.StatusCode(), string(content.Body))
+
+	value, err := content.JSON201.AsHttpDataSourceTyped()
+	require.NoError(t, err)
+	return *value.Id
+}
+
+// CreateCheck creates a check and returns its ID.
+func CreateCheck(t *testing.T, client folge.ClientWithResponsesInterface, applicationId int, datasourceId int, check folge.Check) int {
+	t.Helper()
+
+	content, err := client.ApplicationsDataSourcesChecksCreateWithResponse(
+		context.Background(), applicationId, datasourceId, folge.CheckRequest(check))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, content.StatusCode(), string(content.Body))
+
+	var value struct {
+		Id int `json:"id"`
+	}
+	data, err := content.JSON201.MarshalJSON()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &value))
+	return value.Id
+}
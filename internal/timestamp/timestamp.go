@@ -0,0 +1,28 @@
+// Package timestamp handles the RFC 3339 timestamps used as values of checks
+// on datetime properties.
+package timestamp
+
+import "time"
+
+// Parse parses an RFC 3339 timestamp like `2024-01-01T12:00:00Z`, with
+// optional fractional seconds.
+func Parse(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// Equivalent reports whether both timestamps describe the same instant, like
+// `2024-01-01T12:00:00Z` and `2024-01-01T13:00:00.000+01:00`.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ta, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	tb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return ta.Equal(tb)
+}
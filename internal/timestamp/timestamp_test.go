@@ -0,0 +1,53 @@
+package timestamp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, input := range []string{
+		"2024-01-01T12:00:00Z",
+		"2024-01-01T12:00:00.123456Z",
+		"2024-01-01T13:00:00+01:00",
+	} {
+		_, err := Parse(input)
+		assert.NoError(t, err, input)
+	}
+
+	for _, input := range []string{"", "2024-01-01", "2024-01-01 12:00:00Z", "2024-01-01T12:00:00", "yesterday"} {
+		_, err := Parse(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	assert.True(t, Equivalent("2024-01-01T12:00:00Z", "2024-01-01T13:00:00+01:00"))
+	assert.True(t, Equivalent("2024-01-01T12:00:00Z", "2024-01-01T12:00:00.000000Z"))
+	assert.True(t, Equivalent("2024-01-01T12:00:00.5Z", "2024-01-01T12:00:00.500+00:00"))
+	assert.False(t, Equivalent("2024-01-01T12:00:00Z", "2024-01-01T12:00:00+01:00"))
+	assert.False(t, Equivalent("2024-01-01T12:00:00Z", "2024-01-01T12:00:00.1Z"))
+	assert.False(t, Equivalent("invalid", "2024-01-01T12:00:00Z"))
+}
+
+func TestValue(t *testing.T) {
+	ctx := context.Background()
+
+	equal, diags := NewValue("2024-01-01T12:00:00Z").StringSemanticEquals(ctx, NewValue("2024-01-01T14:00:00.000+02:00"))
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	resp := &xattr.ValidateAttributeResponse{}
+	NewValue("2024-01-01").ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("value_datetime")}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "not an RFC 3339 timestamp")
+
+	resp = &xattr.ValidateAttributeResponse{}
+	NewUnknown().ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("value_datetime")}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
@@ -0,0 +1,101 @@
+package timestamp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/labd/terraform-provider-folge/internal/stringtype"
+)
+
+var (
+	_ basetypes.StringTypable                    = Type{}
+	_ basetypes.StringValuableWithSemanticEquals = Value{}
+	_ xattr.ValidateableAttribute                = Value{}
+)
+
+// Type is the attribute type of RFC 3339 timestamps. Values are validated
+// when the configuration is validated, and timestamps describing the same
+// instant in different offsets are considered equal.
+type Type struct {
+	basetypes.StringType
+}
+
+func (t Type) String() string {
+	return "timestamp.Type"
+}
+
+func (t Type) Equal(o attr.Type) bool {
+	other, ok := o.(Type)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t Type) ValueType(_ context.Context) attr.Value {
+	return Value{}
+}
+
+func (t Type) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Value{StringValue: in}, nil
+}
+
+func (t Type) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringtype.ValueFromTerraform(ctx, t, in)
+}
+
+// Value is an RFC 3339 timestamp.
+type Value struct {
+	basetypes.StringValue
+}
+
+// NewValue returns a known timestamp.
+func NewValue(value string) Value {
+	return Value{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewNull returns a null timestamp.
+func NewNull() Value {
+	return Value{StringValue: basetypes.NewStringNull()}
+}
+
+// NewUnknown returns an unknown timestamp.
+func NewUnknown() Value {
+	return Value{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v Value) Type(_ context.Context) attr.Type {
+	return Type{}
+}
+
+func (v Value) Equal(o attr.Value) bool {
+	other, ok := o.(Value)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true when both timestamps describe the same
+// instant.
+func (v Value) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	return stringtype.SemanticEquals(v, newValuable, Equivalent)
+}
+
+// ValidateAttribute checks that the value is a valid RFC 3339 timestamp.
+func (v Value) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := Parse(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timestamp",
+			fmt.Sprintf("The value %q is not an RFC 3339 timestamp like 2024-01-01T12:00:00Z: %s.", v.ValueString(), err))
+	}
+}
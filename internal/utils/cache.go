@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+// Cache holds API results which are needed by many resources during a single
+// plan, so they are only fetched once.
+type Cache struct {
+	mu          sync.Mutex
+	checkCounts map[[2]int]int
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		checkCounts: map[[2]int]int{},
+	}
+}
+
+// ReserveCheck returns the number of checks on a datasource, including the
+// checks reserved earlier in the same plan, and reserves a slot for one more.
+// The checks on the server are only listed once, so each check added in a
+// plan counts towards the limit of the ones planned after it.
+func (c *Cache) ReserveCheck(ctx context.Context, client folge.ClientWithResponsesInterface, applicationId int, datasourceId int) (int, error) {
+	if c == nil {
+		return CountChecks(ctx, client, applicationId, datasourceId)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := [2]int{applicationId, datasourceId}
+	count, ok := c.checkCounts[key]
+	if !ok {
+		var err error
+		count, err = CountChecks(ctx, client, applicationId, datasourceId)
+		if err != nil {
+			return 0, err
+		}
+	}
+	c.checkCounts[key] = count + 1
+	return count, nil
+}
@@ -0,0 +1,38 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/testutils"
+	"github.com/labd/terraform-provider-folge/internal/utils"
+)
+
+func TestReserveCheck(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewClient(t)
+	appId := testutils.CreateApplication(t, client, "Checkout")
+	dsId := testutils.CreateDataSource(t, client, appId, folge.HttpDataSourceTypedRequest{
+		Url: "https://example.com/health",
+	})
+	check := folge.Check{}
+	require.NoError(t, check.FromHttpStatusCheckTyped(folge.HttpStatusCheckTyped{Label: "Status", StatusCode: 200}))
+	testutils.CreateCheck(t, client, appId, dsId, check)
+
+	cache := utils.NewCache()
+	for _, expected := range []int{1, 2, 3} {
+		count, err := cache.ReserveCheck(ctx, client, appId, dsId)
+		require.NoError(t, err)
+		assert.Equal(t, expected, count)
+	}
+
+	// Without a cache nothing is reserved
+	var empty *utils.Cache
+	count, err := empty.ReserveCheck(ctx, client, appId, dsId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+// CountChecks returns the number of checks configured on a datasource.
+func CountChecks(ctx context.Context, client folge.ClientWithResponsesInterface, applicationId int, datasourceId int) (int, error) {
+	content, err := client.ApplicationsDataSourcesChecksListWithResponse(ctx, applicationId, datasourceId)
+	if err != nil {
+		return 0, err
+	}
+	if content.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("status code %d error: %s", content.StatusCode(), string(content.Body))
+	}
+	return len(*content.JSON200), nil
+}
+
+// EnabledValue returns the enabled flag of a check or datasource. Objects
+// without the flag are enabled, which is the default of the API.
+func EnabledValue(enabled *bool) types.Bool {
+	if enabled == nil {
+		return types.BoolValue(true)
+	}
+	return types.BoolValue(*enabled)
+}
+
+// SetWritten stores the model of a check returned by a create or update as the
+// new state. The check exists on the server at this point, so the state is set
+// before reporting a crontab the server didn't store, otherwise a failing
+// apply would leave the check untracked.
+func SetWritten(ctx context.Context, state *tfsdk.State, model any, sent types.String, stored types.String) diag.Diagnostics {
+	diags := state.Set(ctx, model)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(CheckCrontabStored(sent, stored)...)
+	return diags
+}
+
+// CheckCrontabStored returns an error when a crontab was sent but the API
+// didn't return it, which means the server silently dropped the schedule.
+func CheckCrontabStored(sent types.String, stored types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if sent.IsNull() || !stored.IsNull() {
+		return diags
+	}
+	diags.AddAttributeError(
+		path.Root("crontab"),
+		"Crontab not supported",
+		fmt.Sprintf("The Folge server did not store the crontab %q of the check, "+
+			"it probably does not support schedules. Upgrade the server to use this resource.", sent.ValueString()),
+	)
+	return diags
+}
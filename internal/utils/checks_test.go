@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCrontabStored(t *testing.T) {
+	assert.False(t, CheckCrontabStored(types.StringValue("@daily"), types.StringValue("@daily")).HasError())
+	assert.False(t, CheckCrontabStored(types.StringNull(), types.StringNull()).HasError())
+
+	diags := CheckCrontabStored(types.StringValue("@daily"), types.StringNull())
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), "does not support schedules")
+}
@@ -1,13 +1,42 @@
 package utils
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/labd/terraform-provider-folge/internal/capabilities"
 	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/policy"
 )
 
-func GetClient(data any) folge.ClientWithResponsesInterface {
-	c, ok := data.(folge.ClientWithResponsesInterface)
-	if !ok {
-		panic("invalid client type")
+// ProviderData is passed from the provider to the resources during Configure.
+type ProviderData struct {
+	Client folge.ClientWithResponsesInterface
+
+	// URL is the resolved API base URL.
+	URL string
+
+	// Capabilities holds the features supported by the server.
+	Capabilities *capabilities.Capabilities
+	Policy       *policy.Policy
+	Defaults     *CheckDefaults
+	Cache        *Cache
+}
+
+// GetProviderData returns the provider data passed to Configure, or an error
+// diagnostic when it has an unexpected type.
+func GetProviderData(data any) (*ProviderData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	d, ok := data.(*ProviderData)
+	if !ok || d == nil {
+		diags.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *utils.ProviderData, got: %T. "+
+				"Please report this issue to the provider developers.", data),
+		)
+		return nil, diags
 	}
-	return c
+	return d, diags
 }
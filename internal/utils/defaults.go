@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+)
+
+// CheckDefaults holds the provider level defaults for check settings.
+type CheckDefaults struct {
+	Crontab crontab.Value
+	Enabled types.Bool
+}
+
+// Apply sets the crontab and enabled attributes of a planned check to the
+// provider defaults when they are not configured on the resource itself.
+func (d *CheckDefaults) Apply(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	defaults := CheckDefaults{
+		Crontab: crontab.NewNull(),
+		Enabled: types.BoolNull(),
+	}
+	if d != nil {
+		defaults = *d
+	}
+
+	var value crontab.Value
+	diags.Append(config.GetAttribute(ctx, path.Root("crontab"), &value)...)
+	if diags.HasError() {
+		return diags
+	}
+	if value.IsNull() {
+		if defaults.Crontab.IsNull() {
+			diags.AddAttributeError(
+				path.Root("crontab"),
+				"Missing crontab",
+				"The crontab must be set on the resource or in the defaults block of the provider.",
+			)
+			return diags
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root("crontab"), defaults.Crontab)...)
+	}
+
+	var enabled types.Bool
+	diags.Append(config.GetAttribute(ctx, path.Root("enabled"), &enabled)...)
+	if diags.HasError() {
+		return diags
+	}
+	if enabled.IsNull() {
+		if defaults.Enabled.IsNull() {
+			enabled = types.BoolValue(true)
+		} else {
+			enabled = defaults.Enabled
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root("enabled"), enabled)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/crontab"
+)
+
+// applyDefaults plans a check with the configured crontab and enabled flag,
+// and returns the planned values.
+func applyDefaults(t *testing.T, d *CheckDefaults, expr *string, enabled *bool) (crontab.Value, types.Bool, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"crontab": schema.StringAttribute{Optional: true, Computed: true, CustomType: crontab.Type{}},
+			"enabled": schema.BoolAttribute{Optional: true, Computed: true},
+		},
+	}
+	raw := tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"crontab": tftypes.NewValue(tftypes.String, expr),
+		"enabled": tftypes.NewValue(tftypes.Bool, enabled),
+	})
+	plan := tfsdk.Plan{Schema: s, Raw: raw}
+	diags := d.Apply(ctx, tfsdk.Config{Schema: s, Raw: raw}, &plan)
+
+	var value crontab.Value
+	var planned types.Bool
+	require.False(t, plan.GetAttribute(ctx, path.Root("crontab"), &value).HasError())
+	require.False(t, plan.GetAttribute(ctx, path.Root("enabled"), &planned).HasError())
+	return value, planned, diags
+}
+
+func TestCheckDefaults(t *testing.T) {
+	daily := "@daily"
+	hourly := "@hourly"
+	enabled := true
+	defaults := &CheckDefaults{
+		Crontab: crontab.NewValue(daily),
+		Enabled: types.BoolValue(false),
+	}
+
+	// Unset attributes are taken from the defaults
+	value, planned, diags := applyDefaults(t, defaults, nil, nil)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, daily, value.ValueString())
+	assert.False(t, planned.ValueBool())
+
+	// The resource overrides the defaults
+	value, planned, diags = applyDefaults(t, defaults, &hourly, &enabled)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, hourly, value.ValueString())
+	assert.True(t, planned.ValueBool())
+
+	// Checks are enabled when neither sets enabled
+	value, planned, diags = applyDefaults(t, &CheckDefaults{
+		Crontab: crontab.NewValue(daily),
+		Enabled: types.BoolNull(),
+	}, nil, nil)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, daily, value.ValueString())
+	assert.True(t, planned.ValueBool())
+}
+
+func TestCheckDefaultsMissingCrontab(t *testing.T) {
+	for _, d := range []*CheckDefaults{nil, {Crontab: crontab.NewNull(), Enabled: types.BoolValue(true)}} {
+		_, _, diags := applyDefaults(t, d, nil, nil)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Missing crontab", diags[0].Summary())
+	}
+}
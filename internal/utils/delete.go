@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	confirmDeleteAttempts = 5
+	confirmDeleteDelay    = time.Second
+)
+
+// IsDeleted reports whether a delete request succeeded. A 404 response counts
+// as success, so deleting an object which is already gone is not an error.
+func IsDeleted(response ApiResponse) bool {
+	return response.StatusCode() == http.StatusNoContent || response.StatusCode() == http.StatusNotFound
+}
+
+// ConfirmDeleted retrieves a deleted object until the API responds with a 404,
+// to make sure the object is really gone. Only successful responses are
+// retried, any other status is reported right away.
+func ConfirmDeleted(ctx context.Context, name string, id any, retrieve func() (ApiResponse, error)) *diag.ErrorDiagnostic {
+	for attempt := 1; ; attempt++ {
+		response, err := retrieve()
+		if IsNotFound(response, err) {
+			return nil
+		}
+		if err != nil {
+			d := diag.NewErrorDiagnostic(
+				fmt.Sprintf("Error deleting %s", name),
+				fmt.Sprintf("Could not verify %s with id %v was deleted, unexpected error: %s", name, id, err.Error()))
+			return &d
+		}
+		if code := response.StatusCode(); code < 200 || code > 299 {
+			d := diag.NewErrorDiagnostic(
+				fmt.Sprintf("Error deleting %s", name),
+				fmt.Sprintf("Could not verify %s with id %v was deleted, status code: %d (%s)",
+					name, id, code, readResponseBody(response)))
+			return &d
+		}
+		if attempt == confirmDeleteAttempts {
+			d := diag.NewErrorDiagnostic(
+				fmt.Sprintf("Error deleting %s", name),
+				fmt.Sprintf("The %s with id %v still exists after it was deleted, status code: %d (%s)",
+					name, id, response.StatusCode(), readResponseBody(response)))
+			return &d
+		}
+
+		select {
+		case <-ctx.Done():
+			d := diag.NewErrorDiagnostic(
+				fmt.Sprintf("Error deleting %s", name),
+				fmt.Sprintf("Could not verify %s with id %v was deleted: %s", name, id, ctx.Err()))
+			return &d
+		case <-time.After(confirmDeleteDelay):
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResponse struct {
+	Body   []byte
+	status int
+}
+
+func (r fakeResponse) StatusCode() int {
+	return r.status
+}
+
+func TestConfirmDeleted(t *testing.T) {
+	calls := 0
+	d := ConfirmDeleted(context.Background(), "check", 1, func() (ApiResponse, error) {
+		calls++
+		return fakeResponse{status: http.StatusNotFound}, nil
+	})
+	assert.Nil(t, d)
+	assert.Equal(t, 1, calls)
+
+	// Errors other than a 404 are reported instead of retried
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError} {
+		calls = 0
+		d = ConfirmDeleted(context.Background(), "check", 1, func() (ApiResponse, error) {
+			calls++
+			return fakeResponse{status: status, Body: []byte("denied")}, nil
+		})
+		require.NotNil(t, d)
+		assert.Equal(t, 1, calls)
+		assert.Contains(t, d.Detail(), "denied")
+		assert.NotContains(t, d.Detail(), "still exists")
+	}
+}
@@ -104,3 +104,27 @@ func readResponseBody(input ApiResponse) string {
 	}
 	return "(no response body)"
 }
+
+// IsNotFound reports whether the request succeeded with a 404 response.
+func IsNotFound(response ApiResponse, err error) bool {
+	if err != nil || response == nil {
+		return false
+	}
+	if v := reflect.ValueOf(response); v.Kind() == reflect.Ptr && v.IsNil() {
+		return false
+	}
+	return response.StatusCode() == http.StatusNotFound
+}
+
+// NotFoundWarning returns the warning added when an object no longer exists
+// remotely and is removed from the state.
+func NotFoundWarning(name string, id any, reason string) diag.WarningDiagnostic {
+	if reason != "" {
+		reason = ", " + reason
+	}
+	return diag.NewWarningDiagnostic(
+		fmt.Sprintf("The %s with id %v no longer exists", name, id),
+		fmt.Sprintf("The %s with id %v no longer exists%s. It is removed from the state and will be "+
+			"recreated on the next apply.", name, id, reason),
+	)
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+// checkResources maps the check types to the resources managing them.
+var checkResources = map[string]string{
+	"http-status": "folge_check_http_status",
+	"json-value":  "folge_check_json_property",
+}
+
+// dataSourceResources maps the datasource types to the resources managing
+// them.
+var dataSourceResources = map[string]string{
+	"http": "folge_datasource",
+}
+
+// ParseImportID splits an import ID like `1/2/3` into numeric IDs. The names
+// of the parts are used to describe the expected format in errors.
+func ParseImportID(id string, names ...string) ([]int, error) {
+	format := strings.Join(names, "/")
+
+	parts := strings.Split(id, "/")
+	if len(parts) != len(names) {
+		return nil, fmt.Errorf("expected import identifier with format %s, got: %q", format, id)
+	}
+
+	result := make([]int, len(parts))
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("expected import identifier with format %s, got: %q (%s is not a valid ID)", format, id, names[i])
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// CheckType returns an error when the check is not of the expected type.
+func CheckType(check folge.Check, expected string) error {
+	value, err := check.Discriminator()
+	if err != nil {
+		return err
+	}
+	return typeError("check", value, expected, checkResources, "folge_check")
+}
+
+// DataSourceType returns an error when the datasource is not of the expected
+// type.
+func DataSourceType(datasource folge.DataSource, expected string) error {
+	value, err := datasource.Discriminator()
+	if err != nil {
+		return err
+	}
+	return typeError("datasource", value, expected, dataSourceResources, "")
+}
+
+// typeError describes the mismatch between the types, pointing to the resource
+// managing objects of the type. Types added to the server after this provider
+// version can only be managed by the generic resource, if there is one.
+func typeError(kind string, value string, expected string, resources map[string]string, generic string) error {
+	if value == expected {
+		return nil
+	}
+
+	if name, ok := resources[value]; ok {
+		return fmt.Errorf("the %s is of type %q instead of %q, use the %s resource for it", kind, value, expected, name)
+	}
+	if generic != "" {
+		return fmt.Errorf("the %s is of type %q instead of %q, which this provider version doesn't support yet, use the %s resource for it", kind, value, expected, generic)
+	}
+	return fmt.Errorf("the %s is of type %q instead of %q, which this provider version doesn't support yet", kind, value, expected)
+}
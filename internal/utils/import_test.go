@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+func TestParseImportID(t *testing.T) {
+	ids, err := ParseImportID("1/2/3", "application_id", "datasource_id", "id")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	for _, id := range []string{"", "3", "1/2", "1/2/3/4", "1/x/3", "1/-2/3", "1//3"} {
+		_, err := ParseImportID(id, "application_id", "datasource_id", "id")
+		assert.Error(t, err, id)
+	}
+}
+
+func TestCheckType(t *testing.T) {
+	check := folge.Check{}
+	require.NoError(t, check.FromJsonDataCheckTyped(folge.JsonDataCheckTyped{}))
+
+	assert.NoError(t, CheckType(check, "json-value"))
+
+	err := CheckType(check, "http-status")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "folge_check_json_property")
+
+	// Types added to the server later point to the generic resource
+	require.NoError(t, check.UnmarshalJSON([]byte(`{"type": "ping", "id": 3}`)))
+	err = CheckType(check, "http-status")
+	assert.EqualError(t, err, `the check is of type "ping" instead of "http-status", which this provider version doesn't support yet, use the folge_check resource for it`)
+}
+
+func TestDataSourceType(t *testing.T) {
+	datasource := folge.DataSource{}
+	require.NoError(t, datasource.FromHttpDataSourceTyped(folge.HttpDataSourceTyped{}))
+	assert.NoError(t, DataSourceType(datasource, "http"))
+
+	require.NoError(t, datasource.UnmarshalJSON([]byte(`{"type": "prometheus", "id": 2}`)))
+	err := DataSourceType(datasource, "http")
+	assert.EqualError(t, err, `the datasource is of type "prometheus" instead of "http", which this provider version doesn't support yet`)
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+const (
+	namePrefix  = "name:"
+	uuidPrefix  = "uuid:"
+	labelPrefix = "label:"
+)
+
+// listItem holds the fields shared by all datasource and check types.
+type listItem struct {
+	Id    *int    `json:"id"`
+	Label *string `json:"label"`
+}
+
+// SplitImportID splits an import ID like `1/2/label:My check` into the numeric
+// IDs of the parents and the selector of the object itself. The selector may
+// contain slashes.
+func SplitImportID(id string, names ...string) ([]int, string, error) {
+	format := strings.Join(names, "/")
+
+	parts := strings.SplitN(id, "/", len(names))
+	if len(parts) != len(names) || parts[len(parts)-1] == "" {
+		return nil, "", fmt.Errorf("expected import identifier with format %s, got: %q", format, id)
+	}
+
+	var parents []int
+	if len(names) > 1 {
+		var err error
+		parents, err = ParseImportID(strings.Join(parts[:len(parts)-1], "/"), names[:len(names)-1]...)
+		if err != nil {
+			return nil, "", fmt.Errorf("expected import identifier with format %s, got: %q", format, id)
+		}
+	}
+	return parents, parts[len(parts)-1], nil
+}
+
+// ResolveApplicationID returns the ID of the application matching the
+// selector, which is either a numeric ID, `name:<name>` or
+// `uuid:<identifier>`.
+func ResolveApplicationID(ctx context.Context, client folge.ClientWithResponsesInterface, selector string) (int, error) {
+	name, byName := strings.CutPrefix(selector, namePrefix)
+	identifier, byUUID := strings.CutPrefix(selector, uuidPrefix)
+	if !byName && !byUUID {
+		return parseID(selector)
+	}
+
+	content, err := client.ApplicationsListWithResponse(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not list applications: %w", err)
+	}
+	if content.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("could not list applications, status code %d error: %s", content.StatusCode(), string(content.Body))
+	}
+
+	var matches []int
+	for _, app := range *content.JSON200 {
+		if app.Id == nil {
+			continue
+		}
+		if byName && app.Name == name {
+			matches = append(matches, *app.Id)
+		}
+		if byUUID && app.Identifier != nil && strings.EqualFold(app.Identifier.String(), identifier) {
+			matches = append(matches, *app.Id)
+		}
+	}
+	return singleMatch(matches, fmt.Sprintf("application with %s", describe(selector)))
+}
+
+// ResolveDataSourceID returns the ID of the datasource in the application
+// matching the selector, which is either a numeric ID or `label:<label>`.
+func ResolveDataSourceID(ctx context.Context, client folge.ClientWithResponsesInterface, applicationId int, selector string) (int, error) {
+	label, byLabel := strings.CutPrefix(selector, labelPrefix)
+	if !byLabel {
+		return parseID(selector)
+	}
+
+	content, err := client.ApplicationsDataSourcesListWithResponse(ctx, applicationId)
+	if err != nil {
+		return 0, fmt.Errorf("could not list datasources: %w", err)
+	}
+	if content.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("could not list datasources, status code %d error: %s", content.StatusCode(), string(content.Body))
+	}
+
+	items := make([]json.Marshaler, 0, len(*content.JSON200))
+	for _, item := range *content.JSON200 {
+		items = append(items, item)
+	}
+	matches, err := matchLabel(items, label)
+	if err != nil {
+		return 0, err
+	}
+	return singleMatch(matches, fmt.Sprintf("datasource with %s in application %d", describe(selector), applicationId))
+}
+
+// ResolveCheckID returns the ID of the check in the datasource matching the
+// selector, which is either a numeric ID or `label:<label>`.
+func ResolveCheckID(ctx context.Context, client folge.ClientWithResponsesInterface, applicationId int, datasourceId int, selector string) (int, error) {
+	label, byLabel := strings.CutPrefix(selector, labelPrefix)
+	if !byLabel {
+		return parseID(selector)
+	}
+
+	content, err := client.ApplicationsDataSourcesChecksListWithResponse(ctx, applicationId, datasourceId)
+	if err != nil {
+		return 0, fmt.Errorf("could not list checks: %w", err)
+	}
+	if content.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("could not list checks, status code %d error: %s", content.StatusCode(), string(content.Body))
+	}
+
+	items := make([]json.Marshaler, 0, len(*content.JSON200))
+	for _, item := range *content.JSON200 {
+		items = append(items, item)
+	}
+	matches, err := matchLabel(items, label)
+	if err != nil {
+		return 0, err
+	}
+	return singleMatch(matches, fmt.Sprintf("check with %s in datasource %d", describe(selector), datasourceId))
+}
+
+// DataSourceIDs returns the IDs of all datasources in the application, sorted
+// in ascending order.
+func DataSourceIDs(ctx context.Context, client folge.ClientWithResponsesInterface, applicationId int) ([]int, error) {
+	content, err := client.ApplicationsDataSourcesListWithResponse(ctx, applicationId)
+	if err != nil {
+		return nil, fmt.Errorf("could not list datasources: %w", err)
+	}
+	if content.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("could not list datasources, status code %d error: %s", content.StatusCode(), string(content.Body))
+	}
+
+	ids := make([]int, 0, len(*content.JSON200))
+	for _, item := range *content.JSON200 {
+		data, err := item.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		var value listItem
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		if value.Id != nil {
+			ids = append(ids, *value.Id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// matchLabel returns the IDs of the items with the label. Only the shared
+// fields are decoded, so items of every type can be matched.
+func matchLabel(items []json.Marshaler, label string) ([]int, error) {
+	var matches []int
+	for _, item := range items {
+		data, err := item.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		var value listItem
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		if value.Id != nil && value.Label != nil && *value.Label == label {
+			matches = append(matches, *value.Id)
+		}
+	}
+	return matches, nil
+}
+
+func singleMatch(matches []int, description string) (int, error) {
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no %s found", description)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, id := range matches {
+			ids[i] = strconv.Itoa(id)
+		}
+		return 0, fmt.Errorf("multiple matches for %s (IDs %s), import by ID instead",
+			description, strings.Join(ids, ", "))
+	}
+}
+
+func describe(selector string) string {
+	prefix, value, _ := strings.Cut(selector, ":")
+	return fmt.Sprintf("%s %q", prefix, value)
+}
+
+func parseID(selector string) (int, error) {
+	id, err := strconv.Atoi(selector)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%q is not a valid ID", selector)
+	}
+	return id, nil
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+	"github.com/labd/terraform-provider-folge/internal/mock"
+)
+
+func TestSplitImportID(t *testing.T) {
+	parents, selector, err := SplitImportID("1/2/label:a/b", "application_id", "datasource_id", "id")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, parents)
+	assert.Equal(t, "label:a/b", selector)
+
+	parents, selector, err = SplitImportID("name:Checkout", "id")
+	require.NoError(t, err)
+	assert.Empty(t, parents)
+	assert.Equal(t, "name:Checkout", selector)
+
+	for _, id := range []string{"", "1", "1/", "x/label:a"} {
+		_, _, err := SplitImportID(id, "application_id", "id")
+		assert.Error(t, err, id)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	server, err := mock.New("")
+	require.NoError(t, err)
+	client, err := folge.NewClientWithResponses("http://folge.mock", folge.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	var apps []folge.Application
+	for _, name := range []string{"Checkout", "Search", "Search"} {
+		content, err := client.ApplicationsCreateWithResponse(ctx, folge.ApplicationRequest{Name: name})
+		require.NoError(t, err)
+		apps = append(apps, *content.JSON201)
+	}
+
+	id, err := ResolveApplicationID(ctx, client, "name:Checkout")
+	require.NoError(t, err)
+	assert.Equal(t, *apps[0].Id, id)
+
+	id, err = ResolveApplicationID(ctx, client, "uuid:"+apps[1].Identifier.String())
+	require.NoError(t, err)
+	assert.Equal(t, *apps[1].Id, id)
+
+	id, err = ResolveApplicationID(ctx, client, "42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	_, err = ResolveApplicationID(ctx, client, "name:Search")
+	assert.ErrorContains(t, err, "multiple matches")
+
+	_, err = ResolveApplicationID(ctx, client, "name:Other")
+	assert.ErrorContains(t, err, "no application with name \"Other\" found")
+
+	appId := *apps[0].Id
+	ds := folge.DataSourceRequest{}
+	label := "Health"
+	require.NoError(t, ds.FromHttpDataSourceTypedRequest(folge.HttpDataSourceTypedRequest{
+		Label: &label,
+		Url:   "https://example.com/health",
+	}))
+	created, err := client.ApplicationsDataSourcesCreateWithResponse(ctx, appId, ds)
+	require.NoError(t, err)
+	dsValue, err := created.JSON201.AsHttpDataSourceTyped()
+	require.NoError(t, err)
+
+	id, err = ResolveDataSourceID(ctx, client, appId, "label:Health")
+	require.NoError(t, err)
+	assert.Equal(t, *dsValue.Id, id)
+
+	check := folge.Check{}
+	require.NoError(t, check.FromHttpStatusCheckTyped(folge.HttpStatusCheckTyped{
+		Label:      "Status",
+		StatusCode: 200,
+	}))
+	createdCheck, err := client.ApplicationsDataSourcesChecksCreateWithResponse(ctx, appId, *dsValue.Id, folge.CheckRequest(check))
+	require.NoError(t, err)
+	checkValue, err := createdCheck.JSON201.AsHttpStatusCheckTyped()
+	require.NoError(t, err)
+
+	id, err = ResolveCheckID(ctx, client, appId, *dsValue.Id, "label:Status")
+	require.NoError(t, err)
+	assert.Equal(t, *checkValue.Id, id)
+
+	_, err = ResolveCheckID(ctx, client, appId, *dsValue.Id, "label:Other")
+	assert.Error(t, err)
+}
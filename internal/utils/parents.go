@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+// MissingParentReason explains why a datasource or check was not found when
+// one of its parents was deleted. The datasource is only verified when
+// datasourceId is set. An empty string is returned when the parents exist, or
+// when this cannot be determined.
+func MissingParentReason(ctx context.Context, client folge.ClientWithResponsesInterface, applicationId int, datasourceId int) string {
+	app, err := client.ApplicationsRetrieveWithResponse(ctx, applicationId)
+	if IsNotFound(app, err) {
+		return fmt.Sprintf("because the application with id %d was deleted", applicationId)
+	}
+	if datasourceId == 0 {
+		return ""
+	}
+
+	ds, err := client.ApplicationsDataSourcesRetrieveWithResponse(ctx, applicationId, datasourceId)
+	if IsNotFound(ds, err) {
+		return fmt.Sprintf("because the datasource with id %d was deleted", datasourceId)
+	}
+	return ""
+}
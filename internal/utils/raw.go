@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// RawObject is a check or datasource as returned by the API, holding all of
+// its fields. Objects of types which are unknown to this provider version can
+// be read and sent back without losing data.
+type RawObject struct {
+	Type   string
+	Fields map[string]json.RawMessage
+}
+
+// ParseRaw decodes a check or datasource of any type.
+func ParseRaw(value json.Marshaler) (RawObject, error) {
+	data, err := value.MarshalJSON()
+	if err != nil {
+		return RawObject{}, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return RawObject{}, err
+	}
+
+	var discriminator string
+	if err := json.Unmarshal(fields["type"], &discriminator); err != nil {
+		return RawObject{}, fmt.Errorf("missing discriminator field type: %w", err)
+	}
+	delete(fields, "type")
+	return RawObject{Type: discriminator, Fields: fields}, nil
+}
+
+// MarshalJSON encodes the object including its type, with the fields sorted
+// by name.
+func (o RawObject) MarshalJSON() ([]byte, error) {
+	fields := maps.Clone(o.Fields)
+	if fields == nil {
+		fields = map[string]json.RawMessage{}
+	}
+	discriminator, err := json.Marshal(o.Type)
+	if err != nil {
+		return nil, err
+	}
+	fields["type"] = discriminator
+	return json.Marshal(fields)
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-folge/internal/folge"
+)
+
+func TestParseRaw(t *testing.T) {
+	// A check of a type added to the server after this provider version
+	input := `{"type":"ping","id":3,"label":"Ping","host":"example.com","timeout":1.50,"options":{"b":1,"a":[true]}}`
+	check := folge.Check{}
+	require.NoError(t, check.UnmarshalJSON([]byte(input)))
+
+	raw, err := ParseRaw(check)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", raw.Type)
+	assert.Equal(t, json.RawMessage(`1.50`), raw.Fields["timeout"])
+	assert.NotContains(t, raw.Fields, "type")
+
+	// All fields are sent back unchanged
+	data, err := raw.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, input, string(data))
+	assert.Contains(t, string(data), `"timeout":1.50`)
+
+	_, err = ParseRaw(json.RawMessage(`{"id":3}`))
+	assert.Error(t, err)
+}
+
+func TestMatchLabelUnknownType(t *testing.T) {
+	known := folge.Check{}
+	require.NoError(t, known.FromHttpStatusCheckTyped(folge.HttpStatusCheckTyped{Id: ptr(1), Label: "Status"}))
+	unknown := folge.Check{}
+	require.NoError(t, unknown.UnmarshalJSON([]byte(`{"type":"ping","id":2,"label":"Ping","host":"example.com"}`)))
+
+	matches, err := matchLabel([]json.Marshaler{known, unknown}, "Ping")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, matches)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}